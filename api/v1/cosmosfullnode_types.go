@@ -22,6 +22,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -32,6 +33,12 @@ func init() {
 // CosmosFullNodeController is the canonical controller name.
 const CosmosFullNodeController = "CosmosFullNode"
 
+// ResetAnnotation requests the controller wipe and re-bootstrap a single instance.
+// The value is the instance's ordinal. E.g. cosmos.strange.love/reset=2
+// The controller deletes the instance's pod and PVC, recreates the PVC from the configured data source,
+// and removes the annotation once the request is accepted.
+const ResetAnnotation = "cosmos.strange.love/reset"
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -155,6 +162,19 @@ type FullNodeStatus struct {
 	// +mapType:=granular
 	ScheduledSnapshotStatus map[string]FullNodeSnapshotStatus `json:"scheduledSnapshotStatus"`
 
+	// Set by the CosmosFullNodeController while bootstrapping a new PVC from an in-sync sibling's PVC.
+	// Map key is the name of the PVC being created.
+	// +optional
+	// +mapType:=granular
+	SiblingClones map[string]FullNodeSiblingCloneStatus `json:"siblingClones,omitempty"`
+
+	// Set by the CosmosFullNodeController while wiping and re-bootstrapping an instance.
+	// Requested via the cosmos.strange.love/reset annotation.
+	// Map key is the name of the instance (pod) being reset.
+	// +optional
+	// +mapType:=granular
+	Resets map[string]FullNodeResetStatus `json:"resets,omitempty"`
+
 	// Status set by the SelfHealing controller.
 	// +optional
 	SelfHealing SelfHealingStatus `json:"selfHealing,omitempty"`
@@ -167,11 +187,51 @@ type FullNodeStatus struct {
 	// +optional
 	SyncInfo map[string]*SyncInfoPodStatus `json:"sync,omitempty"`
 
+	// Details of the node running in each instance. Collected with SyncInfo.
+	// Map key is the name of the instance (pod).
+	// +optional
+	Instances map[string]*InstanceStatus `json:"instances,omitempty"`
+
 	// Latest Height information. collected when node starts up and when RPC is successfully queried.
 	// +optional
 	Height map[string]uint64 `json:"height,omitempty"`
+
+	// Latest governance upgrade found in each instance's data/upgrade-info.json. Reported with Height.
+	// Map key is the name of the instance (pod).
+	// +optional
+	Upgrades map[string]UpgradeStatus `json:"upgrades,omitempty"`
+
+	// Latest governance upgrade with an image in spec.chain.upgradeImages that each instance passed.
+	// Keeps the upgrade's image applied after a later upgrade without an image replaces it in upgrades.
+	// Map key is the name of the instance (pod).
+	// +optional
+	AppliedUpgrades map[string]UpgradeStatus `json:"appliedUpgrades,omitempty"`
+
+	// Observations of the CosmosFullNode's state, e.g. ChainHalted.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+const (
+	// ConditionChainHalted is true when all pods are stuck at the same height because the chain halted.
+	ConditionChainHalted = "ChainHalted"
+	// ConditionDiverged is true when an instance reported a different block or app hash than the majority of
+	// pods on the same chain at the same height.
+	ConditionDiverged = "Diverged"
+	// ConditionWrongNetwork is true when an instance reports a network other than spec.chain.chainID,
+	// e.g. because of a wrong genesis or snapshot.
+	ConditionWrongNetwork = "WrongNetwork"
+	// ConditionPVCAutoScaleBlocked is true when self-healing cannot expand a PVC that needs expansion, e.g. because
+	// its StorageClass does not allow volume expansion or the daily limit was reached.
+	ConditionPVCAutoScaleBlocked = "PVCAutoScaleBlocked"
+	// ConditionPVCResizeFailed is true when a PVC could not be resized to its requested size.
+	ConditionPVCResizeFailed = "PVCResizeFailed"
+)
+
 type SyncInfoPodStatus struct {
 	// When consensus information was fetched.
 	Timestamp metav1.Time `json:"timestamp"`
@@ -186,12 +246,111 @@ type SyncInfoPodStatus struct {
 	Error *string `json:"error,omitempty"`
 }
 
+type InstanceStatus struct {
+	// When the instance information was fetched.
+	Timestamp metav1.Time `json:"timestamp"`
+	// The CometBFT node ID.
+	// +optional
+	NodeID string `json:"nodeID,omitempty"`
+	// The CometBFT version.
+	// +optional
+	CometBFTVersion string `json:"cometBFTVersion,omitempty"`
+	// The application version reported by /abci_info.
+	// +optional
+	AppVersion string `json:"appVersion,omitempty"`
+	// The chain ID the node is connected to.
+	// +optional
+	Network string `json:"network,omitempty"`
+	// Lowest block height available on the node. Used to verify pruning and archive coverage.
+	// +optional
+	EarliestHeight *uint64 `json:"earliestHeight,omitempty"`
+	// Latest block height on the node.
+	// +optional
+	LatestHeight *uint64 `json:"latestHeight,omitempty"`
+	// Time of the latest block.
+	// +optional
+	LatestBlockTime *metav1.Time `json:"latestBlockTime,omitempty"`
+	// Age of the latest block when fetched.
+	// +optional
+	BlockAge *metav1.Duration `json:"blockAge,omitempty"`
+	// Number of connected peers.
+	// +optional
+	Peers *int32 `json:"peers,omitempty"`
+	// Number of unconfirmed transactions in the mempool.
+	// +optional
+	MempoolSize *int32 `json:"mempoolSize,omitempty"`
+	// If the node reports it is catching up to the chain tip.
+	// +optional
+	CatchingUp bool `json:"catchingUp,omitempty"`
+	// Blocks per second the node processed over the last few minutes.
+	// +optional
+	SyncRate string `json:"syncRate,omitempty"`
+	// Estimated time until a catching up node reaches the highest instance's height, accounting for the chain's
+	// block production rate. Unset if the node is not catching up or is not gaining on the chain.
+	// +optional
+	CatchUpETA *metav1.Duration `json:"catchUpETA,omitempty"`
+	// The image running in the node container.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Total container restarts for the pod.
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
+	// Error message if unable to fetch node information.
+	// +optional
+	Error *string `json:"error,omitempty"`
+	// Set if the node container is crash looping.
+	// +optional
+	CrashLoop *InstanceCrashLoopStatus `json:"crashLoop,omitempty"`
+	// Observations of the instance's state, e.g. Diverged.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type InstanceCrashLoopStatus struct {
+	// The probable cause of the crash loop.
+	Class CrashLoopClass `json:"class"`
+	// The reason the container last terminated, e.g. Error or OOMKilled.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// The exit code of the container's last termination.
+	ExitCode int32 `json:"exitCode"`
+	// The termination message or log line that determined the class.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 type FullNodeSnapshotStatus struct {
 	// Which pod name to temporarily delete. Indicates a ScheduledVolumeSnapshot is taking place. For optimal data
 	// integrity, pod is temporarily removed so PVC does not have any processes writing to it.
 	PodCandidate string `json:"podCandidate"`
 }
 
+type FullNodeSiblingCloneStatus struct {
+	// The sibling pod whose PVC is the clone source. If the clone requires the pod to be temporarily deleted,
+	// the pod is not recreated until the new PVC is bound.
+	SourcePod string `json:"sourcePod"`
+
+	// The PVC used as the dataSource for the new PVC.
+	SourcePVC string `json:"sourcePVC"`
+
+	// When the clone was requested.
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
+type FullNodeResetStatus struct {
+	// The PVC deleted and recreated from the configured data source.
+	PVCName string `json:"pvcName"`
+
+	// The UID of the PVC to be deleted. The reset is complete once a PVC with a different UID exists.
+	// +optional
+	PVCUID types.UID `json:"pvcUID,omitempty"`
+
+	// When the reset was requested.
+	StartedAt metav1.Time `json:"startedAt"`
+}
+
 type FullNodePhase string
 
 const (
@@ -350,15 +509,46 @@ const (
 	FullNodeProbeStrategyNone      FullNodeProbeStrategy = "None"
 	FullNodeProbeStrategyReachable FullNodeProbeStrategy = "Reachable"
 	FullNodeProbeStrategyInSync    FullNodeProbeStrategy = "InSync"
+	// InSyncWithAPIs is InSync and also requires the gRPC and REST API servers to respond.
+	FullNodeProbeStrategyInSyncWithAPIs FullNodeProbeStrategy = "InSyncWithAPIs"
 )
 
 // FullNodeProbesSpec configures probes for created pods
 type FullNodeProbesSpec struct {
 	// Strategy controls the default probes added by the controller.
 	// None = Do not add any probes. May be necessary for Sentries using a remote signer.
-	// +kubebuilder:validation:Enum:=None;Reachable;InSync
+	// Reachable = Only probe the node's RPC.
+	// InSync (default) = Also probe the healthcheck sidecar, which requires the node to be in sync.
+	// InSyncWithAPIs = InSync and the Cosmos SDK gRPC and REST API servers must respond. Requires both to be enabled
+	// in app.toml.
+	// +kubebuilder:validation:Enum:=None;Reachable;InSync;InSyncWithAPIs
 	// +optional
 	Strategy FullNodeProbeStrategy `json:"strategy"`
+
+	// If set, the node is not ready while its latest block time is older than this duration, even if CometBFT
+	// reports it is not catching up. Should be several times the chain's block time.
+	// Only applies to the InSync strategies. If not set, the latest block time is not checked.
+	// +optional
+	MaxBlockAge *metav1.Duration `json:"maxBlockAge,omitempty"`
+
+	// If set, adds startup and liveness probes to the node container which restart it if its RPC
+	// is unreachable. Not applied if strategy is None.
+	// +optional
+	Liveness *FullNodeLivenessSpec `json:"liveness,omitempty"`
+}
+
+// FullNodeLivenessSpec configures the node container's startup and liveness probes.
+type FullNodeLivenessSpec struct {
+	// How long the node's RPC may take to become reachable after the container starts,
+	// such as while replaying blocks or migrating state after an upgrade.
+	// Defaults to 1h.
+	// +optional
+	StartupTimeout *metav1.Duration `json:"startupTimeout,omitempty"`
+
+	// How long the node's RPC may be unreachable once started before the container is restarted.
+	// Defaults to 2m.
+	// +optional
+	FailureTimeout *metav1.Duration `json:"failureTimeout,omitempty"`
 }
 
 // PersistentVolumeClaimSpec describes the common attributes of storage devices
@@ -436,6 +626,30 @@ type AutoDataSource struct {
 	// is restored from a VolumeSnapshot on the same node.
 	// This is useful if the VolumeSnapshots are local to the node, e.g. for topolvm.
 	MatchInstance bool `json:"matchInstance"`
+
+	// If set and no VolumeSnapshot is found (or volumeSnapshotSelector is not set), the PVC is cloned from
+	// the PVC of an in-sync sibling instance instead of starting empty.
+	// Your CSI driver must support volume cloning and the PVCs must be in the same availability zone.
+	// +optional
+	SiblingClone *SiblingCloneSpec `json:"siblingClone"`
+}
+
+// SiblingCloneSpec configures cloning a new replica's PVC from an in-sync sibling.
+type SiblingCloneSpec struct {
+	// If true, the sibling pod is temporarily deleted while its PVC is cloned.
+	// This option prevents writes to the source PVC, ensuring the highest possible data integrity.
+	// The pod is restored once the new PVC is bound.
+	// If false, the PVC is cloned while the sibling is running.
+	// +optional
+	DeletePod bool `json:"deletePod"`
+
+	// Minimum number of in-sync pods required before cloning a sibling's PVC.
+	// If not met, the PVC is created without a data source.
+	// Defaults to 2.
+	// Warning: If set to 1 and deletePod is true, you will experience downtime.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MinAvailable int32 `json:"minAvailable"`
 }
 
 // RolloutStrategy is an update strategy that can be shared between several Cosmos CRDs.
@@ -591,6 +805,13 @@ type ChainSpec struct {
 	// +optional
 	Versions []ChainVersion `json:"versions"`
 
+	// Images for governance upgrades keyed by upgrade name, e.g. "v15": "ghcr.io/strangelove-ventures/heighliner/gaia:v15.0.0".
+	// When a node halts at an upgrade, it writes the upgrade's name and height to data/upgrade-info.json.
+	// The operator then uses the image for that name from the upgrade height on, so the height does not need to be
+	// known in advance. Takes precedence over versions with a lower height.
+	// +optional
+	UpgradeImages map[string]string `json:"upgradeImages,omitempty"`
+
 	// Additional arguments to pass to the chain init command.
 	// +optional
 	AdditionalInitArgs []string `json:"additionalInitArgs"`
@@ -618,6 +839,43 @@ type ChainVersion struct {
 	// Determines if the node should forcefully halt at the upgrade height.
 	// +optional
 	SetHaltHeight bool `json:"setHaltHeight,omitempty"`
+
+	// Additional arguments to pass to the chain start command for this version.
+	// Appended after spec.chain.additionalStartArgs.
+	// +optional
+	AdditionalStartArgs []string `json:"additionalStartArgs,omitempty"`
+
+	// Environment variables for the node container for this version.
+	// Replaces variables of the same name.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Customize config.toml for this version. Merged after spec.chain.config.overrides.
+	// Must be valid toml. Keys must be "snake_case".
+	// +optional
+	ConfigOverrides *string `json:"configOverrides,omitempty"`
+
+	// Customize app.toml for this version. Merged after spec.chain.app.overrides.
+	// Must be valid toml. Keys must be "kebab-case".
+	// +optional
+	AppOverrides *string `json:"appOverrides,omitempty"`
+
+	// Resources for the node container for this version. Replaces spec.podTemplate.resources.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// UpgradeStatus is a governance upgrade read from an instance's data/upgrade-info.json.
+type UpgradeStatus struct {
+	// Name of the upgrade plan.
+	Name string `json:"name"`
+
+	// Height at which the upgrade applies.
+	Height uint64 `json:"height"`
+
+	// True while the node is halted at the upgrade height waiting for the upgraded binary.
+	// +optional
+	Pending bool `json:"pending,omitempty"`
 }
 
 // CometConfig configures the config.toml.
@@ -878,6 +1136,27 @@ type InstanceOverridesSpec struct {
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Starts the instance's pod in debug mode. The chain home PVC is mounted, but the node does not start.
+	// Instead, the node container runs a shell that sleeps until terminated, so you can exec into the pod.
+	// A pod in debug mode is excluded from the RPC service, rollouts, and self-healing until this field is removed.
+	// +optional
+	Debug *InstanceDebugSpec `json:"debug,omitempty"`
+}
+
+type InstanceDebugSpec struct {
+	// Image for the node container. Defaults to the instance's chain image.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command for the node container. Defaults to a shell that sleeps until terminated.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// If true, skips all init containers. Useful if an init container fails, e.g. due to a corrupted
+	// config or data directory.
+	// +optional
+	SkipInitContainers bool `json:"skipInitContainers,omitempty"`
 }
 
 type DisableStrategy string
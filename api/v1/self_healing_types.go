@@ -22,10 +22,169 @@ type SelfHealSpec struct {
 	// +optional
 	PVCAutoScale *PVCAutoScaleSpec `json:"pvcAutoScale"`
 
+	// Automatically increases the node container's memory after it is OOMKilled.
+	//
+	// The requested memory is recorded per instance in status.selfHealing.memoryAutoScale. The CosmosFullNode
+	// controller recreates the instance's pod with the new memory. Requested memory less than the pod template's
+	// memory is ignored, so raising the pod template's memory takes precedence.
+	// +optional
+	MemoryAutoScale *MemoryAutoScaleSpec `json:"memoryAutoScale"`
+
 	// Take action when a pod's height falls behind the max height of all pods AND still reports itself as in-sync.
 	//
 	// +optional
 	HeightDriftMitigation *HeightDriftMitigationSpec `json:"heightDriftMitigation"`
+
+	// Take action when a pod's height stops advancing while the reference height advances.
+	// Unlike HeightDriftMitigation, applies to pods that are catching up.
+	//
+	// +optional
+	StallMitigation *StallMitigationSpec `json:"stallMitigation"`
+
+	// Take action when a pod reports a different block or app hash than the majority of pods on the same chain,
+	// including pods of other CosmosFullNodes, at the same height. Divergence is always reported with the
+	// Diverged condition on the instance's status; this field enables remediation.
+	//
+	// +optional
+	DivergenceMitigation *DivergenceMitigationSpec `json:"divergenceMitigation"`
+
+	// Classify the failure of a crash looping node container from its termination reason and last log lines,
+	// and take an action per class. The classification is always reported on the instance's status;
+	// this field enables remediation.
+	//
+	// +optional
+	CrashLoopRemediation *CrashLoopRemediationSpec `json:"crashLoopRemediation"`
+
+	// Detect network-wide chain halts, e.g. for an upgrade or consensus failure. While the chain is halted,
+	// the ChainHalted condition is true and self-healing does not delete pods.
+	// Detection runs with defaults if self-healing is enabled; use this field to tune it.
+	//
+	// +optional
+	ChainHaltDetection *ChainHaltDetectionSpec `json:"chainHaltDetection"`
+
+	// Trusted RPC endpoints or other CosmosFullNodes for the same chain. Establishes the chain tip, so pods
+	// that lag the reference height are detected even if all pods lag, e.g. with a single replica.
+	//
+	// The reference height is used to detect height drift and stalls, for the healthcheck sidecar's readiness
+	// probe, which removes lagging pods from the RPC service, and for decisions that require in-sync pods,
+	// such as rollouts and sibling clones. A chain halt is not declared if any reference is ahead of the pods.
+	//
+	// +optional
+	ReferenceRPCs []ReferenceRPC `json:"referenceRPCs"`
+
+	// The maximum number of blocks a pod may lag the reference height and still be considered in sync.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	ReferenceMaxLag *int32 `json:"referenceMaxLag"`
+}
+
+type DivergenceMitigationSpec struct {
+	// If true, removes a diverged pod from the RPC service so it does not serve requests.
+	// The pod keeps running for inspection until it is deleted or reset.
+	// +optional
+	Quarantine bool `json:"quarantine"`
+
+	// If true, resets a diverged instance as if requested via the cosmos.strange.love/reset annotation.
+	// The PVC is deleted and recreated from the volumeClaimTemplate's dataSource, which should be a trusted snapshot.
+	// Resets respect the CosmosFullNode.Spec.RolloutStrategy and never reset the last in-sync replica.
+	// +optional
+	Reset bool `json:"reset"`
+}
+
+// CrashLoopClass is the probable cause of a crash looping node container.
+type CrashLoopClass string
+
+const (
+	// The node's state does not match the network's, e.g. "wrong Block.Header.AppHash".
+	CrashLoopAppHashMismatch CrashLoopClass = "AppHashMismatch"
+	// The image does not match the chain's height, e.g. "UPGRADE NEEDED" or "BINARY UPDATED BEFORE TRIGGER".
+	CrashLoopWrongBinary CrashLoopClass = "WrongBinary"
+	// The database is locked or corrupted, e.g. a held LOCK file or missing SST files.
+	CrashLoopDBCorruption CrashLoopClass = "DBCorruption"
+	// The container exceeded its memory limit.
+	CrashLoopOOMKilled CrashLoopClass = "OOMKilled"
+	// The PVC has no space left.
+	CrashLoopDiskFull CrashLoopClass = "DiskFull"
+	// None of the above.
+	CrashLoopUnknown CrashLoopClass = "Unknown"
+)
+
+// CrashLoopAction is a remediation for a class of crash loop.
+// +kubebuilder:validation:Enum:=Event;Reset;ExpandPVC;IncreaseMemory
+type CrashLoopAction string
+
+const (
+	// Record a warning event only.
+	CrashLoopActionEvent CrashLoopAction = "Event"
+	// Reset the instance as if requested via the cosmos.strange.love/reset annotation.
+	CrashLoopActionReset CrashLoopAction = "Reset"
+	// Expand the instance's PVC using selfHeal.pvcAutoScale's increaseQuantity and maxSize.
+	CrashLoopActionExpandPVC CrashLoopAction = "ExpandPVC"
+	// Increase the instance's memory using selfHeal.memoryAutoScale's increaseQuantity, maxSize and cooldown.
+	CrashLoopActionIncreaseMemory CrashLoopAction = "IncreaseMemory"
+)
+
+type CrashLoopRemediationSpec struct {
+	// Action when the node's app hash does not match the network's.
+	// Defaults to Event.
+	// +optional
+	AppHashMismatch CrashLoopAction `json:"appHashMismatch"`
+
+	// Action when the image is wrong for the node's height.
+	// Defaults to Event. Reset is not recommended; set spec.chain.versions instead.
+	// +optional
+	WrongBinary CrashLoopAction `json:"wrongBinary"`
+
+	// Action when the node's database is locked or corrupted.
+	// Defaults to Event.
+	// +optional
+	DBCorruption CrashLoopAction `json:"dbCorruption"`
+
+	// Action when the node container is OOMKilled.
+	// Defaults to Event.
+	// +optional
+	OOMKilled CrashLoopAction `json:"oomKilled"`
+
+	// Action when the PVC is full.
+	// Defaults to Event.
+	// +optional
+	DiskFull CrashLoopAction `json:"diskFull"`
+
+	// Minimum wait between actions for the same instance and class.
+	// Defaults to 15m.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown"`
+}
+
+type ChainHaltDetectionSpec struct {
+	// The chain is considered halted when all pods report the same height and the latest block time is older
+	// than this duration.
+	// Defaults to 5m.
+	// +optional
+	Threshold *metav1.Duration `json:"threshold"`
+}
+
+// ReferenceRPC is a trusted source of the chain tip. Set exactly one of URL or CosmosFullNode.
+type ReferenceRPC struct {
+	// Base URL of a CometBFT RPC endpoint. E.g. https://rpc.example.com:443
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Another CosmosFullNode for the same chain. Its height is the max height of its pods.
+	// The healthcheck sidecar queries its RPC service, assuming the same RPC port as this CosmosFullNode.
+	// +optional
+	CosmosFullNode *ReferenceFullNode `json:"cosmosFullNode,omitempty"`
+}
+
+type ReferenceFullNode struct {
+	// Name of the CosmosFullNode.
+	// +kubebuilder:validation:MinLength:=1
+	Name string `json:"name"`
+
+	// Namespace of the CosmosFullNode. Defaults to the namespace of this CosmosFullNode.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type PVCAutoScaleSpec struct {
@@ -49,6 +208,76 @@ type PVCAutoScaleSpec struct {
 	// Safeguards against storage quotas and costs.
 	// +optional
 	MaxSize resource.Quantity `json:"maxSize"`
+
+	// Expand PVCs before they reach UsedSpacePercentage based on their growth rate.
+	// Useful for fast-growing chains where the disk could fill before a resize completes.
+	// +optional
+	Predictive *PVCPredictiveScaleSpec `json:"predictive"`
+
+	// Minimum time between expansion requests for the same PVC.
+	// Many storage providers limit how often a volume can be modified, e.g. AWS EBS allows one modification
+	// per volume every 6 hours.
+	// If not set, there is no cooldown.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown"`
+
+	// Maximum number of expansion requests for the same PVC in a rolling 24 hour window.
+	// If not set, there is no limit.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	MaxIncreasesPerDay *int32 `json:"maxIncreasesPerDay"`
+
+	// If true, deletes the pod of a PVC that is waiting on a filesystem resize for several minutes.
+	// Some CSI drivers only resize the filesystem while the volume is not mounted (offline expansion).
+	// The CosmosFullNode controller recreates the pod. Pods are deleted one at a time, respecting the
+	// CosmosFullNode.Spec.RolloutStrategy, and never when no other replica is in sync.
+	// +optional
+	RestartForOfflineResize bool `json:"restartForOfflineResize"`
+}
+
+type PVCPredictiveScaleSpec struct {
+	// Expand the PVC when its projected time until full, based on the growth rate over the last few hours,
+	// drops below this duration.
+	// Defaults to 24h.
+	// +optional
+	Horizon *metav1.Duration `json:"horizon"`
+
+	// When expanding due to the projection, increase capacity so the projected usage after this many days
+	// stays below UsedSpacePercentage. The increase is never less than IncreaseQuantity.
+	// Defaults to 7.
+	// +kubebuilder:validation:Minimum:=1
+	// +optional
+	TargetDays *int32 `json:"targetDays"`
+}
+
+type MemoryAutoScaleSpec struct {
+	// How much to increase the instance's memory after an OOM kill.
+	// Either a percentage (e.g. 20%) or a resource memory quantity (e.g. 2Gi).
+	//
+	// The pod template's memory limit is increased if set, otherwise its memory request.
+	// Defaults to 25%.
+	// +optional
+	IncreaseQuantity string `json:"increaseQuantity"`
+
+	// A resource memory quantity (e.g. 64Gi).
+	// When memory reaches >= MaxSize, autoscaling ceases.
+	// Defaults to double the pod template's memory.
+	// +optional
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// Minimum wait between memory increases for the same instance.
+	// Defaults to 30m.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown"`
+
+	// Sets the GOMEMLIMIT env var of the node container to this percentage of its memory limit, so the Go
+	// garbage collector works harder before the container is OOMKilled. Not set if the container has no memory limit
+	// or already sets GOMEMLIMIT, e.g. via podTemplate.containers.
+	// Defaults to 90.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=100
+	// +optional
+	GoMemLimitPercentage *int32 `json:"goMemLimitPercentage"`
 }
 
 type HeightDriftMitigationSpec struct {
@@ -63,10 +292,96 @@ type HeightDriftMitigationSpec struct {
 	Threshold uint32 `json:"threshold"`
 }
 
+type StallMitigationSpec struct {
+	// If a pod's height has not advanced for this duration AND the reference height (the max height of its
+	// siblings) is greater than the pod's height, the pod is deleted. The CosmosFullNodeController creates a new
+	// pod to replace it. Pod deletion respects the CosmosFullNode.Spec.RolloutStrategy.
+	// Defaults to 10m.
+	// +optional
+	StallDuration *metav1.Duration `json:"stallDuration"`
+
+	// Minimum wait after deleting a stalled pod before deleting it again if it remains stalled at the same height.
+	// Doubles after each attempt, up to 24h.
+	// Defaults to 5m.
+	// +optional
+	Backoff *metav1.Duration `json:"backoff"`
+
+	// Number of times a pod stalled at the same height is deleted before giving up and recording a
+	// StallMitigationExhausted warning event. Attempts reset once the pod's height advances.
+	// Defaults to 3.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=20
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts"`
+}
+
 type SelfHealingStatus struct {
 	// PVC auto-scaling status.
 	// +optional
 	PVCAutoScale map[string]*PVCAutoScaleStatus `json:"pvcAutoScaler"`
+
+	// When pods were last deleted to complete an offline filesystem resize of their PVC.
+	// Map key is the instance (pod) name.
+	// +optional
+	OfflineResizeRestarts map[string]metav1.Time `json:"offlineResizeRestarts,omitempty"`
+
+	// Disk usage projections for PVCs with enough usage history. Map key is the PVC name.
+	// Refreshed every few minutes.
+	// +optional
+	PVCProjections map[string]*PVCProjectionStatus `json:"pvcProjections,omitempty"`
+
+	// Stall mitigation status. Map key is the instance (pod) name.
+	// +optional
+	StallMitigation map[string]*StallMitigationStatus `json:"stallMitigation,omitempty"`
+
+	// Crash loop remediation status. Map key is the instance (pod) name.
+	// +optional
+	CrashLoopRemediation map[string]*CrashLoopRemediationStatus `json:"crashLoopRemediation,omitempty"`
+
+	// Memory requested for instances after OOM kills. Map key is the instance (pod) name.
+	// The CosmosFullNode controller applies it to the node container's memory request and limit.
+	// +optional
+	MemoryAutoScale map[string]*MemoryAutoScaleStatus `json:"memoryAutoScale,omitempty"`
+}
+
+type CrashLoopRemediationStatus struct {
+	// The class of crash loop acted upon.
+	Class CrashLoopClass `json:"class"`
+	// The action taken.
+	Action CrashLoopAction `json:"action"`
+	// When the action was taken.
+	LastAction metav1.Time `json:"lastAction"`
+}
+
+type MemoryAutoScaleStatus struct {
+	// The memory requested by the SelfHealing controller.
+	RequestedMemory resource.Quantity `json:"requestedMemory"`
+	// The timestamp the SelfHealing controller requested a memory increase.
+	RequestedAt metav1.Time `json:"requestedAt"`
+}
+
+type StallMitigationStatus struct {
+	// The height at which the pod stalled.
+	Height uint64 `json:"height"`
+	// Number of times the pod was deleted while stalled at Height.
+	Attempts int32 `json:"attempts"`
+	// When the pod was last deleted.
+	LastAttempt metav1.Time `json:"lastAttempt"`
+	// If true, attempts are exhausted. The pod is not deleted again until its height advances.
+	// +optional
+	Exhausted bool `json:"exhausted,omitempty"`
+}
+
+type PVCProjectionStatus struct {
+	// Used bytes on the PVC's filesystem.
+	UsedBytes int64 `json:"usedBytes"`
+	// Growth of used bytes per day over the last few hours.
+	GrowthPerDay resource.Quantity `json:"growthPerDay"`
+	// Projected time until the filesystem is full. Unset if usage is not growing.
+	// +optional
+	TimeToFull *metav1.Duration `json:"timeToFull,omitempty"`
+	// When the projection was made.
+	ProjectedAt metav1.Time `json:"projectedAt"`
 }
 
 type PVCAutoScaleStatus struct {
@@ -74,4 +389,7 @@ type PVCAutoScaleStatus struct {
 	RequestedSize resource.Quantity `json:"requestedSize"`
 	// The timestamp the SelfHealing controller requested a PVC increase.
 	RequestedAt metav1.Time `json:"requestedAt"`
+	// Timestamps of increases requested within the last 24 hours. Used to enforce maxIncreasesPerDay.
+	// +optional
+	RecentRequests []metav1.Time `json:"recentRequests,omitempty"`
 }
@@ -22,6 +22,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -53,6 +54,11 @@ func (in *AutoDataSource) DeepCopyInto(out *AutoDataSource) {
 			(*out)[key] = val
 		}
 	}
+	if in.SiblingClone != nil {
+		in, out := &in.SiblingClone, &out.SiblingClone
+		*out = new(SiblingCloneSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoDataSource.
@@ -65,6 +71,26 @@ func (in *AutoDataSource) DeepCopy() *AutoDataSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChainHaltDetectionSpec) DeepCopyInto(out *ChainHaltDetectionSpec) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainHaltDetectionSpec.
+func (in *ChainHaltDetectionSpec) DeepCopy() *ChainHaltDetectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChainHaltDetectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChainSpec) DeepCopyInto(out *ChainSpec) {
 	*out = *in
@@ -127,6 +153,13 @@ func (in *ChainSpec) DeepCopyInto(out *ChainSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpgradeImages != nil {
+		in, out := &in.UpgradeImages, &out.UpgradeImages
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.AdditionalInitArgs != nil {
 		in, out := &in.AdditionalInitArgs, &out.AdditionalInitArgs
 		*out = make([]string, len(*in))
@@ -166,6 +199,33 @@ func (in *ChainVersion) DeepCopyInto(out *ChainVersion) {
 			(*out)[key] = val
 		}
 	}
+	if in.AdditionalStartArgs != nil {
+		in, out := &in.AdditionalStartArgs, &out.AdditionalStartArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConfigOverrides != nil {
+		in, out := &in.ConfigOverrides, &out.ConfigOverrides
+		*out = new(string)
+		**out = **in
+	}
+	if in.AppOverrides != nil {
+		in, out := &in.AppOverrides, &out.AppOverrides
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChainVersion.
@@ -272,9 +332,95 @@ func (in *CosmosFullNodeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopRemediationSpec) DeepCopyInto(out *CrashLoopRemediationSpec) {
+	*out = *in
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopRemediationSpec.
+func (in *CrashLoopRemediationSpec) DeepCopy() *CrashLoopRemediationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopRemediationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrashLoopRemediationStatus) DeepCopyInto(out *CrashLoopRemediationStatus) {
+	*out = *in
+	in.LastAction.DeepCopyInto(&out.LastAction)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrashLoopRemediationStatus.
+func (in *CrashLoopRemediationStatus) DeepCopy() *CrashLoopRemediationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CrashLoopRemediationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DivergenceMitigationSpec) DeepCopyInto(out *DivergenceMitigationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DivergenceMitigationSpec.
+func (in *DivergenceMitigationSpec) DeepCopy() *DivergenceMitigationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DivergenceMitigationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullNodeLivenessSpec) DeepCopyInto(out *FullNodeLivenessSpec) {
+	*out = *in
+	if in.StartupTimeout != nil {
+		in, out := &in.StartupTimeout, &out.StartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.FailureTimeout != nil {
+		in, out := &in.FailureTimeout, &out.FailureTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeLivenessSpec.
+func (in *FullNodeLivenessSpec) DeepCopy() *FullNodeLivenessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FullNodeLivenessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FullNodeProbesSpec) DeepCopyInto(out *FullNodeProbesSpec) {
 	*out = *in
+	if in.MaxBlockAge != nil {
+		in, out := &in.MaxBlockAge, &out.MaxBlockAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(FullNodeLivenessSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeProbesSpec.
@@ -287,6 +433,38 @@ func (in *FullNodeProbesSpec) DeepCopy() *FullNodeProbesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullNodeResetStatus) DeepCopyInto(out *FullNodeResetStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeResetStatus.
+func (in *FullNodeResetStatus) DeepCopy() *FullNodeResetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FullNodeResetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FullNodeSiblingCloneStatus) DeepCopyInto(out *FullNodeSiblingCloneStatus) {
+	*out = *in
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeSiblingCloneStatus.
+func (in *FullNodeSiblingCloneStatus) DeepCopy() *FullNodeSiblingCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FullNodeSiblingCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FullNodeSnapshotStatus) DeepCopyInto(out *FullNodeSnapshotStatus) {
 	*out = *in
@@ -362,6 +540,20 @@ func (in *FullNodeStatus) DeepCopyInto(out *FullNodeStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.SiblingClones != nil {
+		in, out := &in.SiblingClones, &out.SiblingClones
+		*out = make(map[string]FullNodeSiblingCloneStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Resets != nil {
+		in, out := &in.Resets, &out.Resets
+		*out = make(map[string]FullNodeResetStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	in.SelfHealing.DeepCopyInto(&out.SelfHealing)
 	if in.Peers != nil {
 		in, out := &in.Peers, &out.Peers
@@ -384,6 +576,22 @@ func (in *FullNodeStatus) DeepCopyInto(out *FullNodeStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make(map[string]*InstanceStatus, len(*in))
+		for key, val := range *in {
+			var outVal *InstanceStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(InstanceStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.Height != nil {
 		in, out := &in.Height, &out.Height
 		*out = make(map[string]uint64, len(*in))
@@ -391,6 +599,27 @@ func (in *FullNodeStatus) DeepCopyInto(out *FullNodeStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = make(map[string]UpgradeStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AppliedUpgrades != nil {
+		in, out := &in.AppliedUpgrades, &out.AppliedUpgrades
+		*out = make(map[string]UpgradeStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FullNodeStatus.
@@ -418,6 +647,41 @@ func (in *HeightDriftMitigationSpec) DeepCopy() *HeightDriftMitigationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceCrashLoopStatus) DeepCopyInto(out *InstanceCrashLoopStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceCrashLoopStatus.
+func (in *InstanceCrashLoopStatus) DeepCopy() *InstanceCrashLoopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceCrashLoopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceDebugSpec) DeepCopyInto(out *InstanceDebugSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceDebugSpec.
+func (in *InstanceDebugSpec) DeepCopy() *InstanceDebugSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceDebugSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceOverridesSpec) DeepCopyInto(out *InstanceOverridesSpec) {
 	*out = *in
@@ -443,6 +707,11 @@ func (in *InstanceOverridesSpec) DeepCopyInto(out *InstanceOverridesSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(InstanceDebugSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceOverridesSpec.
@@ -455,6 +724,116 @@ func (in *InstanceOverridesSpec) DeepCopy() *InstanceOverridesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.EarliestHeight != nil {
+		in, out := &in.EarliestHeight, &out.EarliestHeight
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.LatestHeight != nil {
+		in, out := &in.LatestHeight, &out.LatestHeight
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.LatestBlockTime != nil {
+		in, out := &in.LatestBlockTime, &out.LatestBlockTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BlockAge != nil {
+		in, out := &in.BlockAge, &out.BlockAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MempoolSize != nil {
+		in, out := &in.MempoolSize, &out.MempoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CatchUpETA != nil {
+		in, out := &in.CatchUpETA, &out.CatchUpETA
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Error != nil {
+		in, out := &in.Error, &out.Error
+		*out = new(string)
+		**out = **in
+	}
+	if in.CrashLoop != nil {
+		in, out := &in.CrashLoop, &out.CrashLoop
+		*out = new(InstanceCrashLoopStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
+func (in *InstanceStatus) DeepCopy() *InstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryAutoScaleSpec) DeepCopyInto(out *MemoryAutoScaleSpec) {
+	*out = *in
+	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GoMemLimitPercentage != nil {
+		in, out := &in.GoMemLimitPercentage, &out.GoMemLimitPercentage
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryAutoScaleSpec.
+func (in *MemoryAutoScaleSpec) DeepCopy() *MemoryAutoScaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryAutoScaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryAutoScaleStatus) DeepCopyInto(out *MemoryAutoScaleStatus) {
+	*out = *in
+	out.RequestedMemory = in.RequestedMemory.DeepCopy()
+	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryAutoScaleStatus.
+func (in *MemoryAutoScaleStatus) DeepCopy() *MemoryAutoScaleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryAutoScaleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -503,6 +882,21 @@ func (in *Ordinals) DeepCopy() *Ordinals {
 func (in *PVCAutoScaleSpec) DeepCopyInto(out *PVCAutoScaleSpec) {
 	*out = *in
 	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.Predictive != nil {
+		in, out := &in.Predictive, &out.Predictive
+		*out = new(PVCPredictiveScaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxIncreasesPerDay != nil {
+		in, out := &in.MaxIncreasesPerDay, &out.MaxIncreasesPerDay
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCAutoScaleSpec.
@@ -520,6 +914,13 @@ func (in *PVCAutoScaleStatus) DeepCopyInto(out *PVCAutoScaleStatus) {
 	*out = *in
 	out.RequestedSize = in.RequestedSize.DeepCopy()
 	in.RequestedAt.DeepCopyInto(&out.RequestedAt)
+	if in.RecentRequests != nil {
+		in, out := &in.RecentRequests, &out.RecentRequests
+		*out = make([]metav1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCAutoScaleStatus.
@@ -532,6 +933,53 @@ func (in *PVCAutoScaleStatus) DeepCopy() *PVCAutoScaleStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCPredictiveScaleSpec) DeepCopyInto(out *PVCPredictiveScaleSpec) {
+	*out = *in
+	if in.Horizon != nil {
+		in, out := &in.Horizon, &out.Horizon
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.TargetDays != nil {
+		in, out := &in.TargetDays, &out.TargetDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCPredictiveScaleSpec.
+func (in *PVCPredictiveScaleSpec) DeepCopy() *PVCPredictiveScaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCPredictiveScaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PVCProjectionStatus) DeepCopyInto(out *PVCProjectionStatus) {
+	*out = *in
+	out.GrowthPerDay = in.GrowthPerDay.DeepCopy()
+	if in.TimeToFull != nil {
+		in, out := &in.TimeToFull, &out.TimeToFull
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.ProjectedAt.DeepCopyInto(&out.ProjectedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PVCProjectionStatus.
+func (in *PVCProjectionStatus) DeepCopy() *PVCProjectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PVCProjectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeClaimSpec) DeepCopyInto(out *PersistentVolumeClaimSpec) {
 	*out = *in
@@ -608,7 +1056,7 @@ func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 		*out = new(int64)
 		**out = **in
 	}
-	out.Probes = in.Probes
+	in.Probes.DeepCopyInto(&out.Probes)
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]corev1.Volume, len(*in))
@@ -677,6 +1125,41 @@ func (in *Pruning) DeepCopy() *Pruning {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceFullNode) DeepCopyInto(out *ReferenceFullNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceFullNode.
+func (in *ReferenceFullNode) DeepCopy() *ReferenceFullNode {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceFullNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReferenceRPC) DeepCopyInto(out *ReferenceRPC) {
+	*out = *in
+	if in.CosmosFullNode != nil {
+		in, out := &in.CosmosFullNode, &out.CosmosFullNode
+		*out = new(ReferenceFullNode)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReferenceRPC.
+func (in *ReferenceRPC) DeepCopy() *ReferenceRPC {
+	if in == nil {
+		return nil
+	}
+	out := new(ReferenceRPC)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	*out = *in
@@ -735,11 +1218,48 @@ func (in *SelfHealSpec) DeepCopyInto(out *SelfHealSpec) {
 		*out = new(PVCAutoScaleSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MemoryAutoScale != nil {
+		in, out := &in.MemoryAutoScale, &out.MemoryAutoScale
+		*out = new(MemoryAutoScaleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HeightDriftMitigation != nil {
 		in, out := &in.HeightDriftMitigation, &out.HeightDriftMitigation
 		*out = new(HeightDriftMitigationSpec)
 		**out = **in
 	}
+	if in.StallMitigation != nil {
+		in, out := &in.StallMitigation, &out.StallMitigation
+		*out = new(StallMitigationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DivergenceMitigation != nil {
+		in, out := &in.DivergenceMitigation, &out.DivergenceMitigation
+		*out = new(DivergenceMitigationSpec)
+		**out = **in
+	}
+	if in.CrashLoopRemediation != nil {
+		in, out := &in.CrashLoopRemediation, &out.CrashLoopRemediation
+		*out = new(CrashLoopRemediationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ChainHaltDetection != nil {
+		in, out := &in.ChainHaltDetection, &out.ChainHaltDetection
+		*out = new(ChainHaltDetectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReferenceRPCs != nil {
+		in, out := &in.ReferenceRPCs, &out.ReferenceRPCs
+		*out = make([]ReferenceRPC, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ReferenceMaxLag != nil {
+		in, out := &in.ReferenceMaxLag, &out.ReferenceMaxLag
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHealSpec.
@@ -771,6 +1291,77 @@ func (in *SelfHealingStatus) DeepCopyInto(out *SelfHealingStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.OfflineResizeRestarts != nil {
+		in, out := &in.OfflineResizeRestarts, &out.OfflineResizeRestarts
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PVCProjections != nil {
+		in, out := &in.PVCProjections, &out.PVCProjections
+		*out = make(map[string]*PVCProjectionStatus, len(*in))
+		for key, val := range *in {
+			var outVal *PVCProjectionStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(PVCProjectionStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.StallMitigation != nil {
+		in, out := &in.StallMitigation, &out.StallMitigation
+		*out = make(map[string]*StallMitigationStatus, len(*in))
+		for key, val := range *in {
+			var outVal *StallMitigationStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(StallMitigationStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.CrashLoopRemediation != nil {
+		in, out := &in.CrashLoopRemediation, &out.CrashLoopRemediation
+		*out = make(map[string]*CrashLoopRemediationStatus, len(*in))
+		for key, val := range *in {
+			var outVal *CrashLoopRemediationStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(CrashLoopRemediationStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.MemoryAutoScale != nil {
+		in, out := &in.MemoryAutoScale, &out.MemoryAutoScale
+		*out = make(map[string]*MemoryAutoScaleStatus, len(*in))
+		for key, val := range *in {
+			var outVal *MemoryAutoScaleStatus
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(MemoryAutoScaleStatus)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelfHealingStatus.
@@ -848,6 +1439,67 @@ func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SiblingCloneSpec) DeepCopyInto(out *SiblingCloneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SiblingCloneSpec.
+func (in *SiblingCloneSpec) DeepCopy() *SiblingCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SiblingCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StallMitigationSpec) DeepCopyInto(out *StallMitigationSpec) {
+	*out = *in
+	if in.StallDuration != nil {
+		in, out := &in.StallDuration, &out.StallDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StallMitigationSpec.
+func (in *StallMitigationSpec) DeepCopy() *StallMitigationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StallMitigationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StallMitigationStatus) DeepCopyInto(out *StallMitigationStatus) {
+	*out = *in
+	in.LastAttempt.DeepCopyInto(&out.LastAttempt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StallMitigationStatus.
+func (in *StallMitigationStatus) DeepCopy() *StallMitigationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StallMitigationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncInfoPodStatus) DeepCopyInto(out *SyncInfoPodStatus) {
 	*out = *in
@@ -878,3 +1530,18 @@ func (in *SyncInfoPodStatus) DeepCopy() *SyncInfoPodStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
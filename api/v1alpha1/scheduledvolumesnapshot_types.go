@@ -140,6 +140,22 @@ type SnapshotCandidate struct {
 
 	// +optional
 	PodLabels map[string]string `json:"podLabels"`
+
+	// The candidate's latest block height when it was selected.
+	// +optional
+	Height uint64 `json:"height,omitempty"`
+
+	// The candidate's latest app hash when it was selected.
+	// +optional
+	AppHash string `json:"appHash,omitempty"`
+
+	// The candidate's chain ID (network) when it was selected.
+	// +optional
+	ChainID string `json:"chainID,omitempty"`
+
+	// The candidate's chain node container image.
+	// +optional
+	Image string `json:"image,omitempty"`
 }
 
 type SnapshotPhase string
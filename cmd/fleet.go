@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
+	"github.com/strangelove-ventures/cosmos-operator/internal/healthcheck"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	flagNamespace = "namespace"
+	flagOutput    = "output"
+	flagWatch     = "watch"
+	flagInterval  = "interval"
+	flagDiskUsage = "disk-usage"
+)
+
+// FleetCmd lists the state of every CosmosFullNode instance.
+func FleetCmd(scheme *runtime.Scheme) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fleet",
+		Short: "List the state of all CosmosFullNode instances",
+		Long: `List every instance of every CosmosFullNode with its phase, height, lag, sync state, image, chain version,
+pvc usage, restarts, and ScheduledVolumeSnapshot candidacy.
+
+Lag is the number of blocks behind the highest instance of the same CosmosFullNode.
+Disk usage is read from each pod's healthcheck sidecar and requires network access to pod IPs.`,
+		Args:         cobra.NoArgs,
+		RunE:         runFleet(scheme),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP(flagNamespace, "n", "", "only list CosmosFullNodes in this namespace (default all namespaces)")
+	cmd.Flags().StringP(flagOutput, "o", "table", "output format one of 'table' or 'json'")
+	cmd.Flags().BoolP(flagWatch, "w", false, "print again every interval")
+	cmd.Flags().Duration(flagInterval, 10*time.Second, "how often to print in watch mode")
+	cmd.Flags().Bool(flagDiskUsage, true, "query pod healthcheck sidecars for pvc percent used")
+
+	return cmd
+}
+
+func runFleet(scheme *runtime.Scheme) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		namespace, _ := cmd.Flags().GetString(flagNamespace)
+		output, _ := cmd.Flags().GetString(flagOutput)
+		watch, _ := cmd.Flags().GetBool(flagWatch)
+		interval, _ := cmd.Flags().GetDuration(flagInterval)
+		diskUsage, _ := cmd.Flags().GetBool(flagDiskUsage)
+
+		var write func(w io.Writer, instances []fullnode.FleetInstance) error
+		switch output {
+		case "table":
+			write = writeFleetTable
+		case "json":
+			write = writeFleetJSON
+		default:
+			return fmt.Errorf("unknown output format %q", output)
+		}
+
+		config, err := ctrl.GetConfig()
+		if err != nil {
+			return fmt.Errorf("get kube config: %w", err)
+		}
+		kClient, err := client.New(config, client.Options{Scheme: scheme})
+		if err != nil {
+			return fmt.Errorf("create kube client: %w", err)
+		}
+
+		var diskClient fullnode.DiskUsager
+		if diskUsage {
+			diskClient = healthcheck.NewClient(&http.Client{Timeout: 10 * time.Second})
+		}
+		collector := fullnode.NewFleetCollector(kClient, diskClient)
+
+		ctx := cmd.Context()
+		for {
+			instances, err := collector.Collect(ctx, namespace)
+			if err != nil {
+				return err
+			}
+			if err = write(cmd.OutOrStdout(), instances); err != nil {
+				return err
+			}
+			if !watch {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+				_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			}
+		}
+	}
+}
+
+func writeFleetTable(w io.Writer, instances []fullnode.FleetInstance) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAMESPACE\tFULLNODE\tINSTANCE\tPHASE\tPOD\tHEIGHT\tLAG\tIN-SYNC\tIMAGE\tVERSION\tPVC\tUSED\tRESTARTS\tSNAPSHOT")
+	for _, inst := range instances {
+		var (
+			height  = "-"
+			inSync  = "-"
+			version = "-"
+			size    = "-"
+			used    = "-"
+		)
+		if inst.Height > 0 {
+			height = strconv.FormatUint(inst.Height, 10)
+		}
+		if inst.InSync != nil {
+			inSync = strconv.FormatBool(*inst.InSync)
+		}
+		if inst.ChainVersion != nil {
+			version = strconv.FormatUint(inst.ChainVersion.UpgradeHeight, 10)
+		}
+		if inst.PVCSize != nil {
+			size = inst.PVCSize.String()
+		}
+		if inst.PVCUsed != nil {
+			used = strconv.Itoa(*inst.PVCUsed) + "%"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+			inst.Namespace, inst.FullNode, inst.Name, orDash(string(inst.Phase)), orDash(string(inst.PodPhase)),
+			height, inst.Lag, inSync, orDash(inst.Image), version, size, used, inst.Restarts, orDash(inst.SnapshotCandidate),
+		)
+	}
+	return tw.Flush()
+}
+
+func writeFleetJSON(w io.Writer, instances []fullnode.FleetInstance) error {
+	if instances == nil {
+		instances = []fullnode.FleetInstance{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(instances)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -23,9 +23,16 @@ func HealthCheckCmd() *cobra.Command {
 	}
 
 	hc.Flags().String("rpc-host", "http://localhost:26657", "CometBFT rpc endpoint")
+	hc.Flags().String("chain-id", "", "if set, the chain ID the node must report as its network to be ready")
+	hc.Flags().String("home", "", "chain home directory; if set, disk usage and store sizes are exported as metrics")
 	hc.Flags().String("log-format", "console", "'console' or 'json'")
 	hc.Flags().Duration("timeout", 5*time.Second, "how long to wait before timing out requests to rpc-host")
 	hc.Flags().String("addr", fmt.Sprintf(":%d", healthcheck.Port), "listen address for server to bind")
+	hc.Flags().StringSlice("reference-rpc", nil, "CometBFT rpc endpoint establishing the chain tip; may be repeated")
+	hc.Flags().Uint64("reference-max-lag", 10, "max blocks the node may lag the reference rpcs and still be in sync")
+	hc.Flags().Duration("max-block-age", 0, "max age of the node's latest block time to be ready; 0 disables the check")
+	hc.Flags().String("grpc-addr", "", "if set, the Cosmos SDK gRPC server address (e.g. localhost:9090) that must respond to be ready")
+	hc.Flags().String("api-host", "", "if set, the Cosmos SDK REST API endpoint (e.g. http://localhost:1317) that must respond to be ready")
 
 	if err := viper.BindPFlags(hc.Flags()); err != nil {
 		panic(err)
@@ -48,9 +55,32 @@ func startHealthCheckServer(cmd *cobra.Command, args []string) error {
 	)
 	defer func() { _ = zlog.Sync() }()
 
+	var apis []healthcheck.APIChecker
+	if addr := viper.GetString("grpc-addr"); addr != "" {
+		grpcChecker, err := healthcheck.NewGRPCChecker(addr)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = grpcChecker.Close() }()
+		apis = append(apis, grpcChecker)
+	}
+	if host := viper.GetString("api-host"); host != "" {
+		apis = append(apis, healthcheck.NewRESTChecker(httpClient, host))
+	}
+
 	mux := http.NewServeMux()
-	mux.Handle("/", healthcheck.NewComet(logger, cometClient, rpcHost, timeout))
+	comet := healthcheck.NewComet(logger, cometClient, rpcHost, timeout).
+		WithChainID(viper.GetString("chain-id")).
+		WithReferences(viper.GetStringSlice("reference-rpc"), viper.GetUint64("reference-max-lag")).
+		WithMaxBlockAge(viper.GetDuration("max-block-age")).
+		WithAPIs(apis...)
+	// The root path remains the readiness check for existing probes.
+	mux.Handle("/", comet)
+	mux.Handle(healthcheck.ReadinessPath, comet)
+	mux.HandleFunc(healthcheck.LivenessPath, comet.Liveness)
+	mux.HandleFunc(healthcheck.StartupPath, comet.Liveness)
 	mux.HandleFunc("/disk", healthcheck.DiskUsage)
+	mux.Handle("/metrics", healthcheck.NewMetrics(cometClient, rpcHost, viper.GetString("home"), timeout).Handler())
 
 	srv := &http.Server{
 		Addr:         listenAddr,
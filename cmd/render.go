@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	sigyaml "sigs.k8s.io/yaml"
+)
+
+const (
+	flagFile     = "file"
+	flagSnapshot = "snapshot"
+	flagDiff     = "diff"
+)
+
+// RenderCmd prints the resources the operator would create for a CosmosFullNode without contacting a cluster.
+func RenderCmd(scheme *runtime.Scheme) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Print the resources created for a CosmosFullNode",
+		Long: `Build the pods, configmaps, services, pvcs, and rbac for a CosmosFullNode offline and print them as yaml.
+
+Node keys are derived from the instance names unless --snapshot supplies existing configmaps,
+e.g. the output of "kubectl get configmaps,services,pvc -l app.kubernetes.io/name=<name> -o yaml".
+
+With --diff, print only the differences from a previous render and list the pods a spec change restarts.`,
+		Args:         cobra.NoArgs,
+		RunE:         runRender(scheme),
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringP(flagFile, "f", "", "path to CosmosFullNode yaml")
+	cmd.Flags().String(flagSnapshot, "", "path to yaml of existing cluster resources")
+	cmd.Flags().String(flagDiff, "", "path to a previous render to diff against")
+	_ = cmd.MarkFlagRequired(flagFile)
+
+	return cmd
+}
+
+func runRender(scheme *runtime.Scheme) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString(flagFile)
+		snapshotFile, _ := cmd.Flags().GetString(flagSnapshot)
+		diffFile, _ := cmd.Flags().GetString(flagDiff)
+
+		objs, err := readObjects(scheme, file)
+		if err != nil {
+			return err
+		}
+		var crd *cosmosv1.CosmosFullNode
+		for _, obj := range objs {
+			if found, ok := obj.(*cosmosv1.CosmosFullNode); ok {
+				crd = found
+				break
+			}
+		}
+		if crd == nil {
+			return fmt.Errorf("%s: no CosmosFullNode found", file)
+		}
+		if crd.Namespace == "" {
+			crd.Namespace = "default"
+		}
+
+		var snapshot fullnode.RenderSnapshot
+		if snapshotFile != "" {
+			existing, err := readObjects(scheme, snapshotFile)
+			if err != nil {
+				return err
+			}
+			for _, obj := range existing {
+				switch obj := obj.(type) {
+				case *corev1.ConfigMap:
+					snapshot.ConfigMaps = append(snapshot.ConfigMaps, *obj)
+				case *corev1.Service:
+					snapshot.Services = append(snapshot.Services, *obj)
+				case *corev1.PersistentVolumeClaim:
+					snapshot.PVCs = append(snapshot.PVCs, *obj)
+				}
+			}
+		}
+
+		rendered, err := fullnode.Render(cmd.Context(), crd, snapshot)
+		if err != nil {
+			return fmt.Errorf("render %s: %w", crd.Name, err)
+		}
+
+		docs := make([]renderDoc, len(rendered))
+		for i, obj := range rendered {
+			gvk, err := apiutil.GVKForObject(obj, scheme)
+			if err != nil {
+				return err
+			}
+			obj.GetObjectKind().SetGroupVersionKind(gvk)
+			if docs[i], err = newRenderDoc(obj); err != nil {
+				return err
+			}
+		}
+
+		out := cmd.OutOrStdout()
+		if diffFile == "" {
+			for _, doc := range docs {
+				_, _ = fmt.Fprintf(out, "---\n%s", doc.yaml)
+			}
+			return nil
+		}
+
+		previous, err := readRenderDocs(diffFile)
+		if err != nil {
+			return err
+		}
+		return writeRenderDiff(out, previous, docs)
+	}
+}
+
+// readObjects decodes all objects in a multi-document yaml or json file, expanding lists.
+func readObjects(scheme *runtime.Scheme, path string) ([]client.Object, error) {
+	uns, err := readUnstructured(path)
+	if err != nil {
+		return nil, err
+	}
+	var objs []client.Object
+	for _, u := range uns {
+		typed, err := scheme.New(u.GroupVersionKind())
+		if err != nil {
+			// Ignore kinds the operator does not know about.
+			continue
+		}
+		if err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, typed); err != nil {
+			return nil, fmt.Errorf("%s: decode %s %s: %w", path, u.GetKind(), u.GetName(), err)
+		}
+		if obj, ok := typed.(client.Object); ok {
+			objs = append(objs, obj)
+		}
+	}
+	return objs, nil
+}
+
+func readUnstructured(path string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var objs []*unstructured.Unstructured
+	dec := yaml.NewYAMLOrJSONDecoder(f, 4096)
+	for {
+		var u unstructured.Unstructured
+		err = dec.Decode(&u.Object)
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+		if !u.IsList() {
+			objs = append(objs, &u)
+			continue
+		}
+		if err = u.EachListItem(func(item runtime.Object) error {
+			objs = append(objs, item.(*unstructured.Unstructured))
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+}
+
+type renderDoc struct {
+	id       string
+	revision string
+	yaml     string
+}
+
+func newRenderDoc(obj any) (renderDoc, error) {
+	b, err := sigyaml.Marshal(obj)
+	if err != nil {
+		return renderDoc{}, err
+	}
+	var u unstructured.Unstructured
+	if err = sigyaml.Unmarshal(b, &u.Object); err != nil {
+		return renderDoc{}, err
+	}
+	// Marshal again so objects read from a previous render and freshly built objects format identically.
+	if b, err = sigyaml.Marshal(u.Object); err != nil {
+		return renderDoc{}, err
+	}
+	return renderDoc{
+		id:       fmt.Sprintf("%s %s/%s", u.GetKind(), u.GetNamespace(), u.GetName()),
+		revision: u.GetLabels()[kube.RevisionLabel],
+		yaml:     string(b),
+	}, nil
+}
+
+func readRenderDocs(path string) ([]renderDoc, error) {
+	uns, err := readUnstructured(path)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]renderDoc, len(uns))
+	for i, u := range uns {
+		if docs[i], err = newRenderDoc(u.Object); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return docs, nil
+}
+
+// writeRenderDiff writes a unified diff per changed object followed by a summary of pods that would restart.
+func writeRenderDiff(w io.Writer, previous, current []renderDoc) error {
+	prevByID := make(map[string]renderDoc, len(previous))
+	for _, doc := range previous {
+		prevByID[doc.id] = doc
+	}
+
+	var (
+		buf       bytes.Buffer
+		restarted []string
+	)
+	for _, doc := range current {
+		prev, ok := prevByID[doc.id]
+		delete(prevByID, doc.id)
+		if ok && prev.yaml == doc.yaml {
+			continue
+		}
+		if err := difflib.WriteUnifiedDiff(&buf, difflib.UnifiedDiff{
+			A:        difflib.SplitLines(prev.yaml),
+			B:        difflib.SplitLines(doc.yaml),
+			FromFile: "previous " + doc.id,
+			ToFile:   "current " + doc.id,
+			Context:  3,
+		}); err != nil {
+			return err
+		}
+		if ok && strings.HasPrefix(doc.id, "Pod ") && prev.revision != doc.revision {
+			restarted = append(restarted, doc.id)
+		}
+	}
+
+	removed := make([]string, 0, len(prevByID))
+	for id := range prevByID {
+		removed = append(removed, id)
+	}
+	sort.Strings(removed)
+	for _, id := range removed {
+		if err := difflib.WriteUnifiedDiff(&buf, difflib.UnifiedDiff{
+			A:        difflib.SplitLines(prevByID[id].yaml),
+			FromFile: "previous " + id,
+			ToFile:   "current " + id,
+			Context:  3,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if len(restarted) > 0 {
+		_, _ = fmt.Fprintf(&buf, "\nPods restarted:\n")
+		for _, id := range restarted {
+			_, _ = fmt.Fprintf(&buf, "  %s\n", id)
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -5,9 +5,13 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"time"
 
 	"cosmossdk.io/log"
@@ -15,6 +19,8 @@ import (
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/spf13/cobra"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -27,6 +33,10 @@ import (
 const (
 	namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
 
+	// upgradeInfoFile is written to the data directory by the Cosmos SDK upgrade module when the node halts
+	// for a governance upgrade.
+	upgradeInfoFile = "upgrade-info.json"
+
 	flagBackend           = "backend"
 	flagDaemon            = "daemon"
 	flagContinueOnFailure = "continue"
@@ -34,7 +44,7 @@ const (
 	tickTime = 30 * time.Second
 )
 
-// VersionCheckCmd gets the height of this node and updates the status of the crd.
+// VersionCheckCmd gets the height of this node and reports it on this pod for the controller to update the status of the crd.
 // It panics if the wrong image is specified for the pod for the height,
 // restarting the pod so that the correct image is used from the patched height.
 // this command is intended to be run as an init container.
@@ -42,7 +52,7 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "versioncheck",
 		Short: "Confirm correct image used for current node height",
-		Long:  `Open the Cosmos SDK chain database, get the height, update the crd status with the height, then check the image for the height and panic if it is incorrect.`,
+		Long:  `Open the Cosmos SDK chain database, get the height, report the height on this pod's annotations, then check the image for the height and panic if it is incorrect.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			dataDir := os.Getenv("DATA_DIR")
 			backend, _ := cmd.Flags().GetString(flagBackend)
@@ -91,7 +101,7 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 				panic(fmt.Errorf("failed to get crd: %w", err))
 			}
 
-			if len(crd.Spec.ChainSpec.Versions) == 0 {
+			if !fullnode.HasChainVersions(crd) {
 				fmt.Fprintln(cmd.OutOrStdout(), "No versions specified, skipping version check")
 				return
 			}
@@ -117,14 +127,14 @@ func VersionCheckCmd(scheme *runtime.Scheme) *cobra.Command {
 					case <-cmd.Context().Done():
 						return
 					case <-ticker.C:
-						if err := checkVersion(cmd.Context(), nil, kClient, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
+						if err := checkVersion(cmd.Context(), nil, kClient, clientset, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
 							panic(err)
 						}
 						ticker.Reset(tickTime)
 					}
 				}
 			}
-			if err := checkVersion(cmd.Context(), crd, kClient, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
+			if err := checkVersion(cmd.Context(), crd, kClient, clientset, namespacedName, thisPod, dataDir, backend, cmd.OutOrStdout()); err != nil {
 				panic(err)
 			}
 		},
@@ -141,6 +151,7 @@ func checkVersion(
 	ctx context.Context,
 	crd *cosmosv1.CosmosFullNode,
 	kClient client.Client,
+	clientset kubernetes.Interface,
 	namespacedName types.NamespacedName,
 	thisPod *corev1.Pod,
 	dataDir string,
@@ -152,7 +163,8 @@ func checkVersion(
 		if crd == nil {
 			fmt.Fprintf(writer, "Failed to open db: %s. The node is likely running.\n", err)
 			// This is okay, we will read it later if the node shuts down.
-			return nil
+			// Meanwhile, the node's RPC reports its height.
+			return reportVersion(ctx, clientset, thisPod, nil)
 		} else {
 			return fmt.Errorf("failed to open db: %w", err)
 		}
@@ -162,6 +174,11 @@ func checkVersion(
 	height := store.LatestVersion() + 1
 	db.Close()
 
+	upgrade, err := readUpgradeInfo(dataDir, uint64(height))
+	if err != nil {
+		return err
+	}
+
 	if crd == nil {
 		crd = new(cosmosv1.CosmosFullNode)
 		if err := kClient.Get(ctx, namespacedName, crd); err != nil {
@@ -169,16 +186,22 @@ func checkVersion(
 		}
 	}
 
-	if err := patchStatusHeightIfNecessary(ctx, kClient, crd, thisPod.Name, uint64(height)); err != nil {
+	report := fullnode.VersionReport{Height: uint64(height), Upgrade: upgrade}
+	if err := reportVersion(ctx, clientset, thisPod, &report); err != nil {
 		return err
 	}
+	if upgrade != nil && upgrade.Pending {
+		fmt.Fprintf(writer, "Pending upgrade %s at height %d\n", upgrade.Name, upgrade.Height)
+	}
 
-	var image string
-	for _, v := range crd.Spec.ChainSpec.Versions {
-		if uint64(height) < v.UpgradeHeight {
-			break
-		}
-		image = v.Image
+	// Determine the image as the controller will once it records the report.
+	crd = crd.DeepCopy()
+	fullnode.ApplyVersionReports(&crd.Status, crd.Spec.ChainSpec.UpgradeImages, map[string]fullnode.VersionReport{thisPod.Name: report})
+
+	// Without an applicable version, the pod uses the image from the pod template.
+	image := crd.Spec.PodTemplate.Image
+	if vrs := fullnode.InstanceChainVersion(crd, thisPod.Name); vrs != nil {
+		image = vrs.Image
 	}
 
 	var thisPodImage string
@@ -196,31 +219,65 @@ func checkVersion(
 	return nil
 }
 
-func patchStatusHeightIfNecessary(
-	ctx context.Context,
-	kClient client.Client,
-	crd *cosmosv1.CosmosFullNode,
-	instanceName string,
-	height uint64,
-) error {
-	if crd.Status.Height != nil {
-		if h, ok := crd.Status.Height[instanceName]; ok && h == height {
-			// Status is up to date already.
-			return nil
+// readUpgradeInfo returns the upgrade the node wrote to upgrade-info.json when it halted for a governance upgrade.
+// Returns nil if there is none.
+func readUpgradeInfo(dataDir string, height uint64) (*cosmosv1.UpgradeStatus, error) {
+	b, err := os.ReadFile(filepath.Join(dataDir, upgradeInfoFile))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to read %s: %w", upgradeInfoFile, err)
+	}
+	var info struct {
+		Name   string `json:"name"`
+		Height int64  `json:"height"`
+	}
+	if err = json.Unmarshal(b, &info); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", upgradeInfoFile, err)
 	}
+	if info.Name == "" || info.Height <= 0 {
+		return nil, nil
+	}
+	return &cosmosv1.UpgradeStatus{
+		Name:   info.Name,
+		Height: uint64(info.Height),
+		// The node has not committed the upgrade height until the upgraded binary applies the upgrade.
+		Pending: height <= uint64(info.Height),
+	}, nil
+}
 
-	patch := crd.DeepCopy()
-	if patch.Status.Height == nil {
-		patch.Status.Height = make(map[string]uint64)
+// reportVersion annotates thisPod with report for the controller to record in the CosmosFullNode status.
+// If report is nil, removes the annotation.
+func reportVersion(ctx context.Context, clientset kubernetes.Interface, thisPod *corev1.Pod, report *fullnode.VersionReport) error {
+	var val any // A null value removes the annotation.
+	if report != nil {
+		b, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to marshal version report: %w", err)
+		}
+		if thisPod.Annotations[kube.VersionReportAnnotation] == string(b) {
+			// Reported already.
+			return nil
+		}
+		val = string(b)
+	} else if _, ok := thisPod.Annotations[kube.VersionReportAnnotation]; !ok {
+		return nil
 	}
-	patch.Status.Height[instanceName] = height
 
-	if err := kClient.Status().Patch(
-		ctx, patch, client.MergeFrom(crd.DeepCopy()),
-	); err != nil {
-		return fmt.Errorf("failed to patch status: %w", err)
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{kube.VersionReportAnnotation: val},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	pod, err := clientset.CoreV1().Pods(thisPod.Namespace).Patch(ctx, thisPod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch pod: %w", err)
 	}
+	*thisPod = *pod
 
 	return nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -50,6 +51,7 @@ type CosmosFullNodeReconciler struct {
 	podControl                fullnode.PodControl
 	pvcControl                fullnode.PVCControl
 	recorder                  record.EventRecorder
+	resetControl              fullnode.ResetControl
 	serviceControl            fullnode.ServiceControl
 	statusClient              *fullnode.StatusClient
 	serviceAccountControl     fullnode.ServiceAccountControl
@@ -72,8 +74,9 @@ func NewFullNode(
 		nodeKeyCollector:          fullnode.NewNodeKeyCollector(client),
 		peerCollector:             fullnode.NewPeerCollector(client),
 		podControl:                fullnode.NewPodControl(client, cacheController),
-		pvcControl:                fullnode.NewPVCControl(client),
+		pvcControl:                fullnode.NewPVCControl(client, cacheController),
 		recorder:                  recorder,
+		resetControl:              fullnode.NewResetControl(client, cacheController, statusClient),
 		serviceControl:            fullnode.NewServiceControl(client),
 		statusClient:              statusClient,
 		serviceAccountControl:     fullnode.NewServiceAccountControl(client),
@@ -94,6 +97,7 @@ var (
 //+kubebuilder:rbac:groups="",resources=pods;persistentvolumeclaims;services;serviceaccounts;configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete;bind;escalate
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;update;patch
+//+kubebuilder:rbac:groups="storage.k8s.io",resources=storageclasses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -111,6 +115,9 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		// Also, will get "not found" error if crd is deleted.
 		// No need to explicitly delete resources. Kube GC does so automatically because we set the controller reference
 		// for each resource.
+		if kube.IsNotFound(err) {
+			fullnode.DeleteInstanceMetrics(req.NamespacedName)
+		}
 		return stopResult, client.IgnoreNotFound(err)
 	}
 
@@ -119,10 +126,19 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	fullnode.ResetStatus(crd)
 
 	syncInfo := fullnode.SyncInfoStatus(ctx, crd, r.cacheController)
+	instances := fullnode.InstanceStatus(ctx, crd, r.cacheController)
+	fullnode.ReportSyncStalls(reporter, crd.Status.Instances, instances)
+	fullnode.ReportDivergence(reporter, crd.Status.Instances, instances)
+	fullnode.ReportWrongNetwork(reporter, crd.Status.Instances, instances)
+	fullnode.RecordInstanceMetrics(crd, instances)
+	crd.Status.Instances = instances
+	// Apply before building pods, so pods use the image for their reported height.
+	versionReports := fullnode.VersionReports(ctx, crd, r.cacheController)
+	fullnode.ApplyVersionReports(&crd.Status, crd.Spec.ChainSpec.UpgradeImages, versionReports)
 
 	pvcStatusChanges := fullnode.PVCStatusChanges{}
 
-	defer r.updateStatus(ctx, crd, syncInfo, &pvcStatusChanges)
+	defer r.updateStatus(ctx, crd, syncInfo, versionReports, &pvcStatusChanges)
 
 	errs := &kube.ReconcileErrors{}
 
@@ -172,6 +188,12 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		errs.Append(err)
 	}
 
+	// Reset instances prior to reconciling pods, so requested pods are deleted.
+	resetRequeue, err := r.resetControl.Reconcile(ctx, reporter, crd)
+	if err != nil {
+		errs.Append(err)
+	}
+
 	// Reconcile pods.
 	podRequeue, err := r.podControl.Reconcile(ctx, reporter, crd, configCksums, syncInfo)
 	if err != nil {
@@ -188,7 +210,7 @@ func (r *CosmosFullNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return r.resultWithErr(crd, errs)
 	}
 
-	if podRequeue || pvcRequeue {
+	if resetRequeue || podRequeue || pvcRequeue {
 		return requeueResult, nil
 	}
 
@@ -226,6 +248,7 @@ func (r *CosmosFullNodeReconciler) updateStatus(
 	ctx context.Context,
 	crd *cosmosv1.CosmosFullNode,
 	syncInfo map[string]*cosmosv1.SyncInfoPodStatus,
+	versionReports map[string]fullnode.VersionReport,
 	pvcStatusChanges *fullnode.PVCStatusChanges,
 ) {
 	if err := r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
@@ -233,7 +256,16 @@ func (r *CosmosFullNodeReconciler) updateStatus(
 		status.Phase = crd.Status.Phase
 		status.StatusMessage = crd.Status.StatusMessage
 		status.Peers = crd.Status.Peers
+		status.SiblingClones = crd.Status.SiblingClones
+		status.Resets = crd.Status.Resets
 		status.SyncInfo = syncInfo
+		status.Instances = crd.Status.Instances
+		// Other conditions belong to the SelfHealing controller.
+		if cond := meta.FindStatusCondition(crd.Status.Conditions, cosmosv1.ConditionPVCResizeFailed); cond != nil {
+			meta.SetStatusCondition(&status.Conditions, *cond)
+		}
+		// Heights from running nodes take precedence over reports.
+		fullnode.ApplyVersionReports(status, crd.Spec.ChainSpec.UpgradeImages, versionReports)
 		for k, v := range syncInfo {
 			if v.Height != nil && *v.Height > 0 {
 				if status.Height == nil {
@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
@@ -28,6 +29,9 @@ import (
 	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
 	"github.com/strangelove-ventures/cosmos-operator/internal/healthcheck"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,10 +42,15 @@ import (
 type SelfHealingReconciler struct {
 	client.Client
 	cacheController *cosmos.CacheController
+	haltDetector    fullnode.ChainHaltDetection
 	diskClient      *fullnode.DiskUsageCollector
 	driftDetector   fullnode.DriftDetection
+	memAutoScaler   *fullnode.MemoryAutoScaler
 	pvcAutoScaler   *fullnode.PVCAutoScaler
+	pvcGrowth       *fullnode.PVCGrowth
 	recorder        record.EventRecorder
+	stallDetector   fullnode.StallDetection
+	statusClient    *fullnode.StatusClient
 }
 
 func NewSelfHealing(
@@ -54,10 +63,15 @@ func NewSelfHealing(
 	return &SelfHealingReconciler{
 		Client:          client,
 		cacheController: cacheController,
+		haltDetector:    fullnode.NewChainHaltDetection(cacheController, cosmos.NewCometClient(httpClient)),
 		diskClient:      fullnode.NewDiskUsageCollector(healthcheck.NewClient(httpClient), client),
 		driftDetector:   fullnode.NewDriftDetection(cacheController),
+		memAutoScaler:   fullnode.NewMemoryAutoScaler(statusClient),
 		pvcAutoScaler:   fullnode.NewPVCAutoScaler(statusClient),
+		pvcGrowth:       fullnode.NewPVCGrowth(),
 		recorder:        recorder,
+		stallDetector:   fullnode.NewStallDetection(cacheController),
+		statusClient:    statusClient,
 	}
 }
 
@@ -74,6 +88,9 @@ func (r *SelfHealingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Also, will get "not found" error if crd is deleted.
 		// No need to explicitly delete resources. Kube GC does so automatically because we set the controller reference
 		// for each resource.
+		if kube.IsNotFound(err) {
+			fullnode.DeletePVCMetrics(req.NamespacedName)
+		}
 		return stopResult, client.IgnoreNotFound(err)
 	}
 
@@ -83,13 +100,50 @@ func (r *SelfHealingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	reporter := kube.NewEventReporter(logger, r.recorder, crd)
 
-	r.pvcAutoScale(ctx, reporter, crd)
-	r.mitigateHeightDrift(ctx, reporter, crd)
+	halted := r.detectChainHalt(ctx, reporter, crd)
+
+	r.pvcAutoScale(ctx, reporter, crd, halted)
+	r.memoryAutoScale(ctx, reporter, crd)
+	r.mitigateDivergence(ctx, reporter, crd, halted)
+	r.remediateCrashLoops(ctx, reporter, crd, halted)
+	// Deleting pods cannot help while the whole chain is halted.
+	if halted {
+		reporter.Debug("Chain halted; skipping pod deletions")
+	} else {
+		r.mitigateHeightDrift(ctx, reporter, crd)
+		r.mitigateStalls(ctx, reporter, crd)
+	}
 
 	return ctrl.Result{RequeueAfter: 60 * time.Second}, nil
 }
 
-func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+func (r *SelfHealingReconciler) detectChainHalt(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) bool {
+	halt := r.haltDetector.Detect(ctx, crd)
+	cond := halt.Condition(crd.Generation)
+
+	prev := meta.FindStatusCondition(crd.Status.Conditions, cond.Type)
+	switch {
+	case halt.Halted && (prev == nil || prev.Status != metav1.ConditionTrue):
+		reporter.Info("Chain halted", "height", halt.Height, "blockTime", halt.BlockTime)
+		reporter.RecordError("ChainHalted", errors.New(cond.Message))
+	case !halt.Halted && prev != nil && prev.Status == metav1.ConditionTrue:
+		reporter.Info("Chain resumed")
+		reporter.RecordInfo("ChainResumed", "Chain halt cleared")
+	}
+
+	if prev != nil && prev.Status == cond.Status && prev.Reason == cond.Reason && prev.Message == cond.Message &&
+		prev.ObservedGeneration == cond.ObservedGeneration {
+		return halt.Halted
+	}
+	if err := r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		meta.SetStatusCondition(&status.Conditions, cond)
+	}); err != nil {
+		reporter.Error(err, "Failed to patch chain halted condition")
+	}
+	return halt.Halted
+}
+
+func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, halted bool) {
 	if crd.Spec.SelfHeal.PVCAutoScale == nil {
 		return
 	}
@@ -100,6 +154,20 @@ func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.
 		reporter.RecordError("PVCAutoScaleCollectUsage", errors.New("failed to collect pvc disk usage"))
 		return
 	}
+	r.pvcGrowth.Observe(crd, usage)
+	fullnode.RecordPVCMetrics(crd, usage)
+	if projections, changed := fullnode.PVCProjections(crd.Status.SelfHealing.PVCProjections, usage, time.Now()); changed {
+		if err = r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+			status.SelfHealing.PVCProjections = projections
+		}); err != nil {
+			reporter.Error(err, "Failed to patch pvc projections")
+		}
+	}
+	r.setPVCAutoScaleCondition(ctx, reporter, crd, usage)
+	// Deleting pods cannot help while the whole chain is halted.
+	if !halted {
+		r.restartForOfflineResize(ctx, reporter, crd, usage)
+	}
 	didSignal, err := r.pvcAutoScaler.SignalPVCResize(ctx, crd, usage)
 	if err != nil {
 		reporter.Error(err, "Failed to signal pvc resize")
@@ -114,6 +182,72 @@ func (r *SelfHealingReconciler) pvcAutoScale(ctx context.Context, reporter kube.
 	reporter.RecordInfo("PVCAutoScale", msg)
 }
 
+func (r *SelfHealingReconciler) setPVCAutoScaleCondition(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, usage []fullnode.PVCDiskUsage) {
+	cond := fullnode.PVCAutoScaleCondition(crd, usage, time.Now())
+	prev := meta.FindStatusCondition(crd.Status.Conditions, cond.Type)
+	if prev == nil && cond.Status != metav1.ConditionTrue {
+		return
+	}
+	if prev != nil && prev.Status == cond.Status && prev.Reason == cond.Reason && prev.Message == cond.Message &&
+		prev.ObservedGeneration == cond.ObservedGeneration {
+		return
+	}
+	if cond.Status == metav1.ConditionTrue && (prev == nil || prev.Status != metav1.ConditionTrue) {
+		reporter.Info("PVC auto scaling blocked", "reason", cond.Reason, "message", cond.Message)
+		reporter.RecordError("PVCAutoScaleBlocked", errors.New(cond.Message))
+	}
+	if err := r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		meta.SetStatusCondition(&status.Conditions, cond)
+	}); err != nil {
+		reporter.Error(err, "Failed to patch pvc auto scale condition")
+	}
+}
+
+// restartForOfflineResize deletes a pod whose PVC is waiting on a filesystem resize that requires the volume
+// to be unmounted.
+func (r *SelfHealingReconciler) restartForOfflineResize(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, usage []fullnode.PVCDiskUsage) {
+	if !crd.Spec.SelfHeal.PVCAutoScale.RestartForOfflineResize {
+		return
+	}
+	coll := r.cacheController.Collect(ctx, client.ObjectKeyFromObject(crd))
+	pod, ok := r.pvcAutoScaler.OfflineResizePod(crd, usage, coll)
+	if !ok {
+		return
+	}
+	// Record first, so the recreated pod is not deleted again while the PVC still reports a pending resize.
+	if err := r.pvcAutoScaler.RecordOfflineResizeRestart(ctx, crd, pod.Name); err != nil {
+		reporter.Error(err, "Failed to patch offline resize restart", "pod", pod.Name)
+		return
+	}
+	// CosmosFullNodeController will detect missing pod and re-create it.
+	if err := r.Delete(ctx, pod); kube.IgnoreNotFound(err) != nil {
+		reporter.Error(err, "Failed to delete pod", "pod", pod.Name)
+		reporter.RecordError("PVCAutoScaleRestartPod", err)
+		return
+	}
+	msg := fmt.Sprintf("Deleted pod %s to complete offline filesystem resize of its PVC", pod.Name)
+	reporter.Info(msg)
+	reporter.RecordInfo("PVCAutoScaleRestartPod", msg)
+}
+
+func (r *SelfHealingReconciler) memoryAutoScale(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+	if crd.Spec.SelfHeal.MemoryAutoScale == nil {
+		return
+	}
+	pods := r.cacheController.Collect(ctx, client.ObjectKeyFromObject(crd)).Pods()
+	increased, err := r.memAutoScaler.SignalOOMKilled(ctx, crd, pods)
+	if err != nil {
+		reporter.Error(err, "Failed to signal memory increase")
+		reporter.RecordError("MemoryAutoScaleSignalIncrease", err)
+	}
+	if len(increased) == 0 {
+		return
+	}
+	msg := fmt.Sprintf("Memory auto scaling requested more memory for OOMKilled instance(s) %s", strings.Join(increased, ", "))
+	reporter.Info(msg)
+	reporter.RecordInfo("MemoryAutoScale", msg)
+}
+
 func (r *SelfHealingReconciler) mitigateHeightDrift(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
 	if crd.Spec.SelfHeal.HeightDriftMitigation == nil {
 		return
@@ -137,6 +271,203 @@ func (r *SelfHealingReconciler) mitigateHeightDrift(ctx context.Context, reporte
 	}
 }
 
+func (r *SelfHealingReconciler) mitigateStalls(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) {
+	if crd.Spec.SelfHeal.StallMitigation == nil {
+		return
+	}
+
+	result := r.stallDetector.Detect(ctx, crd)
+	var restarted []fullnode.StalledPod
+	for _, stalled := range result.Restart {
+		// CosmosFullNodeController will detect missing pod and re-create it.
+		if err := r.Delete(ctx, stalled.Pod); kube.IgnoreNotFound(err) != nil {
+			reporter.Error(err, "Failed to delete pod", "pod", stalled.Pod.Name)
+			reporter.RecordError("StallMitigationDeletePod", err)
+			continue
+		}
+		reporter.Info("Deleted stalled pod", "pod", stalled.Pod.Name, "height", stalled.Height, "referenceHeight", stalled.ReferenceHeight, "since", stalled.Since)
+		reporter.RecordInfo("StallMitigation", fmt.Sprintf("Pod %s stalled at height %d since %s while reference height is %d; deleted pod",
+			stalled.Pod.Name, stalled.Height, stalled.Since.Format(time.RFC3339), stalled.ReferenceHeight))
+		restarted = append(restarted, stalled)
+	}
+	for _, stalled := range result.Exhausted {
+		reporter.RecordError("StallMitigationExhausted", fmt.Errorf("pod %s remains stalled at height %d after max restart attempts; human intervention required",
+			stalled.Pod.Name, stalled.Height))
+	}
+
+	if len(restarted) == 0 && len(result.Exhausted) == 0 && len(result.Recovered) == 0 {
+		return
+	}
+	now := time.Now()
+	if err := r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		fullnode.ApplyStallResult(status, restarted, result, now)
+	}); err != nil {
+		reporter.Error(err, "Failed to patch stall mitigation status")
+	}
+}
+
+func (r *SelfHealingReconciler) mitigateDivergence(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, halted bool) {
+	spec := crd.Spec.SelfHeal.DivergenceMitigation
+	if spec == nil {
+		return
+	}
+
+	diverged := fullnode.DivergedPods(r.cacheController.Collect(ctx, client.ObjectKeyFromObject(crd)))
+	if len(diverged) == 0 {
+		return
+	}
+
+	if spec.Quarantine {
+		for _, item := range diverged {
+			pod := item.GetPod()
+			quarantined := pod.DeepCopy()
+			if !fullnode.Quarantine(quarantined) {
+				continue
+			}
+			if err := r.Patch(ctx, quarantined, client.MergeFrom(pod)); kube.IgnoreNotFound(err) != nil {
+				reporter.Error(err, "Failed to quarantine pod", "pod", pod.Name)
+				reporter.RecordError("DivergenceMitigationQuarantine", err)
+				continue
+			}
+			msg := fmt.Sprintf("Removed diverged pod %s from the RPC service", pod.Name)
+			reporter.Info(msg)
+			reporter.RecordInfo("DivergenceMitigationQuarantine", msg)
+		}
+	}
+
+	// Resetting cannot help while the whole chain is halted.
+	if !spec.Reset || halted {
+		return
+	}
+	for _, item := range diverged {
+		pod := item.GetPod()
+		requested, err := r.requestReset(ctx, crd, pod)
+		if err != nil {
+			reporter.Error(err, "Failed to request reset", "pod", pod.Name)
+			reporter.RecordError("DivergenceMitigationReset", err)
+			return
+		}
+		if requested {
+			msg := fmt.Sprintf("Requested reset of diverged instance %s", pod.Name)
+			reporter.Info(msg)
+			reporter.RecordInfo("DivergenceMitigationReset", msg)
+			return
+		}
+	}
+}
+
+// requestReset sets the reset annotation for the pod's instance.
+// Returns false if a reset is already pending, because ResetControl accepts one request at a time,
+// or if the instance is being reset.
+func (r *SelfHealingReconciler) requestReset(ctx context.Context, crd *cosmosv1.CosmosFullNode, pod *corev1.Pod) (bool, error) {
+	if _, ok := crd.Annotations[cosmosv1.ResetAnnotation]; ok {
+		return false, nil
+	}
+	if _, ok := crd.Status.Resets[pod.Name]; ok {
+		return false, nil
+	}
+	obj := crd.DeepCopy()
+	patch := client.MergeFrom(crd.DeepCopy())
+	if obj.Annotations == nil {
+		obj.Annotations = make(map[string]string)
+	}
+	obj.Annotations[cosmosv1.ResetAnnotation] = pod.Annotations[kube.OrdinalAnnotation]
+	if err := r.Patch(ctx, obj, patch); err != nil {
+		return false, err
+	}
+	// Prevent a second request in the same reconcile.
+	crd.Annotations = obj.Annotations
+	return true, nil
+}
+
+func (r *SelfHealingReconciler) remediateCrashLoops(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, halted bool) {
+	spec := crd.Spec.SelfHeal.CrashLoopRemediation
+	if spec == nil {
+		return
+	}
+
+	var (
+		loops = fullnode.CrashLoops(r.cacheController.Collect(ctx, client.ObjectKeyFromObject(crd)))
+		acted = make(map[string]cosmosv1.CrashLoopAction)
+		now   = time.Now()
+	)
+	for _, cl := range loops {
+		if !fullnode.CrashLoopDue(crd, cl, now) {
+			continue
+		}
+		var (
+			pod    = cl.Pod
+			class  = cl.Status.Class
+			action = fullnode.CrashLoopAction(spec, class)
+		)
+		reporter.Info("Pod crash looping", "pod", pod.Name, "class", class, "action", action, "message", cl.Status.Message)
+		reporter.RecordError("CrashLoop", fmt.Errorf("pod %s crash looping with class %s: %s", pod.Name, class, cl.Status.Message))
+
+		var err error
+		switch action {
+		case cosmosv1.CrashLoopActionEvent:
+		case cosmosv1.CrashLoopActionReset:
+			// Resetting cannot help while the whole chain is halted.
+			if halted {
+				continue
+			}
+			var requested bool
+			if requested, err = r.requestReset(ctx, crd, pod); err == nil && !requested {
+				// Try again next reconcile.
+				continue
+			}
+		case cosmosv1.CrashLoopActionExpandPVC:
+			err = r.expandPVC(ctx, crd, pod)
+		case cosmosv1.CrashLoopActionIncreaseMemory:
+			err = r.increaseMemory(ctx, crd, pod)
+		}
+		if err != nil {
+			// Not recorded as acted upon, so the action is retried next reconcile.
+			reporter.Error(err, "Failed to remediate crash loop", "pod", pod.Name, "action", action)
+			reporter.RecordError("CrashLoopRemediation", fmt.Errorf("%s for pod %s: %w", action, pod.Name, err))
+			continue
+		}
+		if action != cosmosv1.CrashLoopActionEvent {
+			reporter.RecordInfo("CrashLoopRemediation", fmt.Sprintf("%s for crash looping pod %s with class %s", action, pod.Name, class))
+		}
+		acted[pod.Name] = action
+	}
+
+	if len(acted) == 0 && len(crd.Status.SelfHealing.CrashLoopRemediation) == 0 {
+		return
+	}
+	if err := r.statusClient.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		fullnode.ApplyCrashLoopRemediation(crd, status, loops, acted, now)
+	}); err != nil {
+		reporter.Error(err, "Failed to patch crash loop remediation status")
+	}
+}
+
+// expandPVC requests expansion of the pod's PVC through the PVC auto scaler as if the PVC were full.
+func (r *SelfHealingReconciler) expandPVC(ctx context.Context, crd *cosmosv1.CosmosFullNode, pod *corev1.Pod) error {
+	if crd.Spec.SelfHeal.PVCAutoScale == nil {
+		return errors.New("selfHeal.pvcAutoScale is not set")
+	}
+	usage, err := r.diskClient.PVCUsage(ctx, pod)
+	if err != nil {
+		return err
+	}
+	usage.PercentUsed = 100
+	if cond := fullnode.PVCAutoScaleCondition(crd, []fullnode.PVCDiskUsage{usage}, time.Now()); cond.Status == metav1.ConditionTrue {
+		return errors.New(cond.Message)
+	}
+	_, err = r.pvcAutoScaler.SignalPVCResize(ctx, crd, []fullnode.PVCDiskUsage{usage})
+	return err
+}
+
+// increaseMemory requests more memory for the pod's instance through the memory auto scaler as if it were OOMKilled.
+func (r *SelfHealingReconciler) increaseMemory(ctx context.Context, crd *cosmosv1.CosmosFullNode, pod *corev1.Pod) error {
+	if crd.Spec.SelfHeal.MemoryAutoScale == nil {
+		return errors.New("selfHeal.memoryAutoScale is not set")
+	}
+	return r.memAutoScaler.SignalMemoryIncrease(ctx, crd, pod)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *SelfHealingReconciler) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
 	// We do not have to index Pods because the CosmosFullNodeReconciler already does so.
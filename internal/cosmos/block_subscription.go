@@ -0,0 +1,108 @@
+package cosmos
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BlockSubscriber streams new blocks from a CometBFT RPC endpoint.
+type BlockSubscriber interface {
+	// SubscribeNewBlocks calls onBlock for every new block until ctx is cancelled or the subscription fails.
+	SubscribeNewBlocks(ctx context.Context, rpcHost string, onBlock func(Block)) error
+}
+
+// podSubscriptions manages a NewBlock subscription for each pod of a single controller.
+// Sync and Live must be called from the same goroutine.
+type podSubscriptions struct {
+	blocks     BlockSubscriber
+	cache      *cache
+	controller client.ObjectKey
+	logger     kube.Logger
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	subs map[types.UID]*podSubscription
+	wg   sync.WaitGroup
+}
+
+type podSubscription struct {
+	host   string
+	cancel context.CancelFunc
+	live   atomic.Bool
+}
+
+// Sync starts subscriptions for new pods and stops subscriptions for pods that are gone or have a new IP.
+func (s *podSubscriptions) Sync(ctx context.Context, pods []corev1.Pod) {
+	want := make(map[types.UID]string)
+	for i := range pods {
+		if pods[i].Status.PodIP != "" {
+			want[pods[i].UID] = rpcHost(&pods[i])
+		}
+	}
+
+	for uid, sub := range s.subs {
+		if want[uid] != sub.host {
+			sub.cancel()
+			delete(s.subs, uid)
+		}
+	}
+
+	for i := range pods {
+		pod := pods[i]
+		host, ok := want[pod.UID]
+		if _, exists := s.subs[pod.UID]; !ok || exists {
+			continue
+		}
+		cctx, cancel := context.WithCancel(ctx)
+		sub := &podSubscription{host: host, cancel: cancel}
+		s.subs[pod.UID] = sub
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.run(cctx, pod.Name, pod.UID, sub)
+		}()
+	}
+}
+
+// Live returns true if the pod's subscription is connected and has delivered at least one block.
+func (s *podSubscriptions) Live(uid types.UID) bool {
+	sub, ok := s.subs[uid]
+	return ok && sub.live.Load()
+}
+
+// Close stops all subscriptions and waits for them to exit.
+func (s *podSubscriptions) Close() {
+	for _, sub := range s.subs {
+		sub.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *podSubscriptions) run(ctx context.Context, podName string, uid types.UID, sub *podSubscription) {
+	backoff := s.minBackoff
+	for {
+		err := s.blocks.SubscribeNewBlocks(ctx, sub.host, func(block Block) {
+			sub.live.Store(true)
+			backoff = s.minBackoff
+			s.cache.UpdateBlock(s.controller, uid, block, time.Now())
+		})
+		sub.live.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Debug("NewBlock subscription failed; falling back to polling", "pod", podName, "error", err, "retry", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
@@ -2,14 +2,22 @@ package cosmos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,17 +27,21 @@ import (
 
 type cache struct {
 	sync.RWMutex
-	m map[client.ObjectKey]*cacheItem
+	m      map[client.ObjectKey]*cacheItem
+	hashes *hashTracker
 }
 
 type cacheItem struct {
-	coll   StatusCollection
-	cancel context.CancelFunc
+	coll      StatusCollection
+	history   heightHistory
+	cancel    context.CancelFunc
+	reference referenceState
 }
 
 func newCache() *cache {
 	return &cache{
-		m: make(map[client.ObjectKey]*cacheItem),
+		m:      make(map[client.ObjectKey]*cacheItem),
+		hashes: newHashTracker(),
 	}
 }
 
@@ -47,8 +59,9 @@ func (c *cache) Init(key client.ObjectKey, cancel context.CancelFunc) {
 	c.Lock()
 	defer c.Unlock()
 	c.m[key] = &cacheItem{
-		coll:   make(StatusCollection, 0),
-		cancel: cancel,
+		coll:    make(StatusCollection, 0),
+		history: make(heightHistory),
+		cancel:  cancel,
 	}
 }
 
@@ -60,6 +73,80 @@ func (c *cache) Update(key client.ObjectKey, value StatusCollection) {
 		return
 	}
 	v.coll = value
+	v.history.Observe(v.coll)
+	c.hashes.Observe(v.coll, time.Now())
+}
+
+// Merge replaces cached items with items for the same pod, adds new items, and removes pods not in pods.
+func (c *cache) Merge(key client.ObjectKey, items StatusCollection, pods []corev1.Pod) {
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return
+	}
+	byUID := lo.SliceToMap(items, func(item StatusItem) (types.UID, StatusItem) { return item.GetPod().UID, item })
+	merged := make(StatusCollection, 0, len(pods))
+	for _, item := range v.coll {
+		if found, ok := byUID[item.GetPod().UID]; ok {
+			item = found
+			delete(byUID, item.GetPod().UID)
+		}
+		merged = append(merged, item)
+	}
+	for _, item := range items {
+		if _, ok := byUID[item.GetPod().UID]; ok {
+			merged = append(merged, item)
+		}
+	}
+	IntersectPods(&merged, pods)
+	sort.Sort(merged)
+	v.coll = merged
+	v.history.Observe(v.coll)
+	c.hashes.Observe(v.coll, time.Now())
+}
+
+// UpdateBlock advances the pod's cached sync info to block. Pods without a successfully collected status
+// are skipped so node info and catching up state are always from a full status.
+func (c *cache) UpdateBlock(key client.ObjectKey, uid types.UID, block Block, ts time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return
+	}
+	for i, item := range v.coll {
+		if item.GetPod().UID != uid {
+			continue
+		}
+		if item.Err != nil || block.Height <= item.Status.LatestBlockHeight() {
+			return
+		}
+		// Copy on write; readers may hold the previous collection.
+		coll := slices.Clone(v.coll)
+		info := &coll[i].Status.Result.SyncInfo
+		info.LatestBlockHeight = strconv.FormatUint(block.Height, 10)
+		info.LatestBlockTime = block.Time
+		if block.Hash != "" {
+			info.LatestBlockHash = block.Hash
+		}
+		// Blocks do not include the app hash resulting from the block.
+		info.LatestAppHash = ""
+		coll[i].TS = ts
+		v.coll = coll
+		v.history.Observe(v.coll)
+		c.hashes.Observe(v.coll, ts)
+		return
+	}
+}
+
+// History calls fn with the controller's height history while holding a read lock.
+func (c *cache) History(key client.ObjectKey, fn func(heightHistory)) {
+	c.RLock()
+	defer c.RUnlock()
+	if v, ok := c.m[key]; ok {
+		fn(v.history)
+	}
 }
 
 func (c *cache) Del(key client.ObjectKey) {
@@ -88,25 +175,59 @@ const CacheControllerName = "CosmosCache"
 
 // CacheController periodically polls pods for their CometBFT status and caches the result.
 // The cache is a controller so it can watch CosmosFullNode objects to warm or invalidate the cache.
+//
+// Each CosmosFullNode is polled on its own jittered schedule so polls do not align across CRDs. The interval
+// adapts: it drops to minInterval while pods change, catch up, or error, and backs off toward maxInterval
+// while all pods are healthy and in sync.
 type CacheController struct {
-	cache     *cache
-	client    client.Reader
-	collector Collector
-	eg        errgroup.Group
-	interval  time.Duration
-	recorder  record.EventRecorder
+	cache       *cache
+	client      client.Reader
+	collector   Collector
+	eg          errgroup.Group
+	interval    time.Duration
+	minInterval time.Duration
+	maxInterval time.Duration
+	jitter      func(time.Duration) time.Duration
+	recorder    record.EventRecorder
+
+	blocks             BlockSubscriber
+	subscribedInterval time.Duration
+	minBackoff         time.Duration
+	maxBackoff         time.Duration
+
+	reference         Statuser
+	referenceInterval time.Duration
+	referenceTimeout  time.Duration
+	referenceTTL      time.Duration
 }
 
 func NewCacheController(collector Collector, reader client.Reader, recorder record.EventRecorder) *CacheController {
 	return &CacheController{
-		cache:     newCache(),
-		client:    reader,
-		collector: collector,
-		interval:  5 * time.Second,
-		recorder:  recorder,
+		cache:              newCache(),
+		client:             reader,
+		collector:          collector,
+		interval:           5 * time.Second,
+		minInterval:        2 * time.Second,
+		maxInterval:        30 * time.Second,
+		jitter:             jitter,
+		recorder:           recorder,
+		subscribedInterval: 30 * time.Second,
+		minBackoff:         time.Second,
+		maxBackoff:         time.Minute,
+		referenceInterval:  15 * time.Second,
+		referenceTimeout:   5 * time.Second,
+		referenceTTL:       2 * time.Minute,
 	}
 }
 
+// SubscribeNewBlocks updates the cache as blocks arrive on each pod's tm.event='NewBlock' subscription.
+// Pods without a live subscription are polled as usual. Pods with a live subscription are polled less often
+// to refresh status not included in blocks, such as catching up. Failed subscriptions reconnect with backoff.
+// Must be called before SetupWithManager.
+func (c *CacheController) SubscribeNewBlocks(blocks BlockSubscriber) {
+	c.blocks = blocks
+}
+
 // SetupWithManager watches CosmosFullNode objects and starts cache collecting.
 func (c *CacheController) SetupWithManager(_ context.Context, mgr ctrl.Manager) error {
 	// We do not index pods because we presume another controller is already doing so.
@@ -144,7 +265,12 @@ func (c *CacheController) Reconcile(ctx context.Context, req reconcile.Request)
 			c.collectFromPods(cctx, reporter, req.NamespacedName)
 			return nil
 		})
+		c.eg.Go(func() error {
+			c.pollReferencesLoop(cctx, req.NamespacedName)
+			return nil
+		})
 	}
+	c.cache.SetReferences(req.NamespacedName, crd)
 
 	return finishResult, nil
 }
@@ -166,16 +292,39 @@ func (c *CacheController) Invalidate(controller client.ObjectKey, pods []string)
 }
 
 // Collect returns a StatusCollection for the given controller. Only returns cached CometStatus.
+// Items include the pod's SyncRate, HeightChangedAt, and LastBlock from cached height history and
+// its Divergence, if any, from hashes reported by all pods of the same chain.
 func (c *CacheController) Collect(ctx context.Context, controller client.ObjectKey) StatusCollection {
 	pods, err := c.listPods(ctx, controller)
 	if err != nil {
 		return nil
 	}
 	v, _ := c.cache.Get(controller)
+	// Copy so the cached collection is not mutated.
+	v = slices.Clone(v)
 	IntersectPods(&v, pods)
 	for i := range pods {
 		UpsertPod(&v, &pods[i])
 	}
+	c.cache.History(controller, func(history heightHistory) {
+		for i := range v {
+			uid := v[i].GetPod().UID
+			v[i].HeightChangedAt, _ = history.HeightChangedAt(uid)
+			if block, ok := history.LastBlock(uid); ok {
+				v[i].LastBlock = &block
+			}
+			if rate, ok := history.Rate(uid); ok && v[i].Err == nil {
+				v[i].SyncRate = &rate
+			}
+		}
+	})
+	tip, hasTip := c.cache.ReferenceTip(controller, time.Now(), c.referenceTTL)
+	for i := range v {
+		v[i].Divergence = c.cache.hashes.Divergence(v[i].GetPod().UID)
+		if hasTip {
+			v[i].Reference = &tip
+		}
+	}
 	return v
 }
 
@@ -198,26 +347,91 @@ func (c *CacheController) listPods(ctx context.Context, controller client.Object
 func (c *CacheController) collectFromPods(ctx context.Context, reporter kube.Reporter, controller client.ObjectKey) {
 	defer c.cache.Del(controller)
 
-	collect := func() {
+	var subs *podSubscriptions
+	if c.blocks != nil {
+		subs = &podSubscriptions{
+			blocks:     c.blocks,
+			cache:      c.cache,
+			controller: controller,
+			logger:     reporter,
+			minBackoff: c.minBackoff,
+			maxBackoff: c.maxBackoff,
+			subs:       make(map[types.UID]*podSubscription),
+		}
+		defer subs.Close()
+	}
+
+	var lastFullPoll time.Time
+	collect := func() ([]corev1.Pod, bool) {
 		pods, err := c.listPods(ctx, controller)
 		if err != nil {
 			err = fmt.Errorf("%s: %w", controller, err)
 			reporter.Error(err, "Failed to list pods")
 			reporter.RecordError("ListPods", err)
-			return
+			return nil, false
+		}
+		if subs == nil {
+			c.cache.Update(controller, c.collector.Collect(ctx, pods))
+			return pods, true
+		}
+
+		subs.Sync(ctx, pods)
+		now := time.Now()
+		full := now.Sub(lastFullPoll) >= c.subscribedInterval
+		if full {
+			lastFullPoll = now
+		}
+		cached, _ := c.cache.Get(controller)
+		cachedByUID := lo.SliceToMap(cached, func(item StatusItem) (types.UID, StatusItem) { return item.GetPod().UID, item })
+		poll := lo.Filter(pods, func(pod corev1.Pod, _ int) bool {
+			item, ok := cachedByUID[pod.UID]
+			return full || !ok || item.Err != nil || !subs.Live(pod.UID)
+		})
+		var polled StatusCollection
+		if len(poll) > 0 {
+			polled = c.collector.Collect(ctx, poll)
 		}
-		c.cache.Update(controller, c.collector.Collect(ctx, pods))
+		c.cache.Merge(controller, polled, pods)
+		return pods, true
 	}
 
-	collect() // Collect once immediately.
-	tick := time.NewTicker(c.interval)
-	defer tick.Stop()
+	var (
+		interval = c.interval
+		prevPods map[types.UID]bool
+	)
 	for {
+		// The first collection is immediate.
+		if pods, ok := collect(); ok {
+			uids := lo.SliceToMap(pods, func(pod corev1.Pod) (types.UID, bool) { return pod.UID, true })
+			cached, _ := c.cache.Get(controller)
+			interval = c.nextInterval(interval, cached, !maps.Equal(uids, prevPods))
+			prevPods = uids
+		}
+		timer := time.NewTimer(c.jitter(interval))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-tick.C:
-			collect()
+		case <-timer.C:
 		}
 	}
 }
+
+// nextInterval returns how long to wait before the next collection given the current interval and collection.
+func (c *CacheController) nextInterval(cur time.Duration, coll StatusCollection, podsChanged bool) time.Duration {
+	for _, item := range coll {
+		// Circuit breakers already limit requests to failing pods, so they do not speed up polling.
+		if (item.Err != nil && !errors.Is(item.Err, errCircuitOpen)) || item.Status.Result.SyncInfo.CatchingUp {
+			return c.minInterval
+		}
+	}
+	if podsChanged {
+		return min(cur, c.interval)
+	}
+	return min(cur*3/2, c.maxInterval)
+}
+
+// jitter returns d +/- 20%.
+func jitter(d time.Duration) time.Duration {
+	return time.Duration(float64(d) * (0.8 + 0.4*rand.Float64()))
+}
@@ -2,6 +2,8 @@ package cosmos
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -15,6 +17,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -259,3 +262,136 @@ func TestCacheController_SyncedPods(t *testing.T) {
 	require.Zero(t, listOpt.Limit)
 	require.Equal(t, ".metadata.controller=axelar", listOpt.FieldSelector.String())
 }
+
+type mockBlockSubscriber func(ctx context.Context, rpcHost string, onBlock func(Block)) error
+
+func (fn mockBlockSubscriber) SubscribeNewBlocks(ctx context.Context, rpcHost string, onBlock func(Block)) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return fn(ctx, rpcHost, onBlock)
+}
+
+type mockPodCollector func(ctx context.Context, pods []corev1.Pod) StatusCollection
+
+func (fn mockPodCollector) Collect(ctx context.Context, pods []corev1.Pod) StatusCollection {
+	return fn(ctx, pods)
+}
+
+func TestCacheController_SubscribeNewBlocks(t *testing.T) {
+	t.Parallel()
+
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	ctx := context.Background()
+	key := client.ObjectKey{Name: "osmosis", Namespace: "default"}
+
+	reader := new(mockReader)
+	reader.ListPods = []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{UID: "subscribed"}, Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		{ObjectMeta: metav1.ObjectMeta{UID: "polled"}, Status: corev1.PodStatus{PodIP: "10.0.0.2"}},
+	}
+
+	var polls sync.Map
+	collector := mockPodCollector(func(ctx context.Context, pods []corev1.Pod) StatusCollection {
+		return lo.Map(pods, func(pod corev1.Pod, _ int) StatusItem {
+			n, _ := polls.LoadOrStore(pod.UID, new(atomic.Int64))
+			n.(*atomic.Int64).Add(1)
+			var status CometStatus
+			status.Result.SyncInfo.LatestBlockHeight = "10"
+			return StatusItem{Pod: &pod, Status: status, TS: time.Now()}
+		})
+	})
+	pollCount := func(uid string) int64 {
+		n, ok := polls.Load(types.UID(uid))
+		if !ok {
+			return 0
+		}
+		return n.(*atomic.Int64).Load()
+	}
+
+	var attempts atomic.Int64
+	subscriber := mockBlockSubscriber(func(ctx context.Context, rpcHost string, onBlock func(Block)) error {
+		if rpcHost != "http://10.0.0.1:26657" {
+			attempts.Add(1)
+			return errors.New("websocket unavailable")
+		}
+		for h := uint64(11); ; h++ {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Millisecond):
+				onBlock(Block{Height: h, Hash: "hash"})
+			}
+		}
+	})
+
+	controller := NewCacheController(collector, reader, nil)
+	controller.interval = 5 * time.Millisecond
+	controller.minInterval = time.Millisecond
+	controller.maxInterval = 5 * time.Millisecond
+	controller.subscribedInterval = time.Hour
+	controller.minBackoff = time.Millisecond
+	controller.SubscribeNewBlocks(subscriber)
+
+	_, err := controller.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	find := func(uid string) StatusItem {
+		item, _ := lo.Find(controller.Collect(ctx, key), func(item StatusItem) bool { return string(item.Pod.UID) == uid })
+		return item
+	}
+	height := func(uid string) uint64 { return find(uid).Status.LatestBlockHeight() }
+	require.Eventually(t, func() bool { return height("subscribed") > 11 }, time.Second, time.Millisecond)
+	require.Equal(t, "hash", find("subscribed").Status.Result.SyncInfo.LatestBlockHash)
+
+	// Polling stops for the live subscription but continues for the failed one.
+	subscribedPolls := pollCount("subscribed")
+	polledPolls := pollCount("polled")
+	require.Eventually(t, func() bool { return pollCount("polled") > polledPolls+3 }, time.Second, time.Millisecond)
+	require.Equal(t, subscribedPolls, pollCount("subscribed"))
+	require.EqualValues(t, 10, height("polled"))
+	require.Greater(t, attempts.Load(), int64(1))
+
+	require.NoError(t, controller.Close())
+}
+
+func TestCacheController_nextInterval(t *testing.T) {
+	t.Parallel()
+
+	controller := NewCacheController(nil, nil, nil)
+	controller.interval = 10 * time.Second
+	controller.minInterval = 2 * time.Second
+	controller.maxInterval = 30 * time.Second
+
+	var healthy StatusItem
+	catchingUp := StatusItem{}
+	catchingUp.Status.Result.SyncInfo.CatchingUp = true
+	erroring := StatusItem{Err: errors.New("boom")}
+	circuitOpen := StatusItem{Err: fmt.Errorf("%w: boom", errCircuitOpen)}
+
+	for _, tt := range []struct {
+		Name        string
+		Cur         time.Duration
+		Coll        StatusCollection
+		PodsChanged bool
+		Want        time.Duration
+	}{
+		{"healthy backs off", 10 * time.Second, StatusCollection{healthy, healthy}, false, 15 * time.Second},
+		{"healthy capped", 25 * time.Second, StatusCollection{healthy}, false, 30 * time.Second},
+		{"empty", 10 * time.Second, nil, false, 15 * time.Second},
+		{"catching up", 30 * time.Second, StatusCollection{healthy, catchingUp}, false, 2 * time.Second},
+		{"erroring", 30 * time.Second, StatusCollection{erroring, healthy}, false, 2 * time.Second},
+		{"circuit open", 10 * time.Second, StatusCollection{circuitOpen, healthy}, false, 15 * time.Second},
+		{"pods changed", 30 * time.Second, StatusCollection{healthy}, true, 10 * time.Second},
+		{"pods changed while fast", 2 * time.Second, StatusCollection{healthy}, true, 2 * time.Second},
+	} {
+		require.Equal(t, tt.Want, controller.nextInterval(tt.Cur, tt.Coll, tt.PodsChanged), tt.Name)
+	}
+
+	for i := 0; i < 100; i++ {
+		got := jitter(10 * time.Second)
+		require.GreaterOrEqual(t, got, 8*time.Second)
+		require.LessOrEqual(t, got, 12*time.Second)
+	}
+}
@@ -0,0 +1,96 @@
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// Consecutive failures before a pod's circuit opens.
+	breakerThreshold = 3
+	// How long the circuit stays open. Doubles after each failed trial request up to the max.
+	breakerMinCooldown = 10 * time.Second
+	breakerMaxCooldown = 5 * time.Minute
+	// Breakers for pods not seen within this duration are forgotten.
+	breakerTTL = 10 * time.Minute
+)
+
+var errCircuitOpen = errors.New("circuit open")
+
+// circuitBreakers tracks consecutive status failures per pod so dead pods do not consume a timeout every cycle.
+// While a pod's circuit is open, requests fail fast. Once the cooldown expires, a single trial request is allowed;
+// success closes the circuit and failure reopens it with a longer cooldown.
+type circuitBreakers struct {
+	mu        sync.Mutex
+	m         map[types.UID]*circuitBreaker
+	lastPrune time.Time
+}
+
+type circuitBreaker struct {
+	failures  int
+	cooldown  time.Duration
+	openUntil time.Time
+	lastErr   error
+	lastSeen  time.Time
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{m: make(map[types.UID]*circuitBreaker)}
+}
+
+// Allow returns an error wrapping errCircuitOpen and the last failure if the pod's circuit is open.
+func (cb *circuitBreakers) Allow(uid types.UID, now time.Time) error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	b, ok := cb.m[uid]
+	if !ok {
+		return nil
+	}
+	b.lastSeen = now
+	if b.failures >= breakerThreshold && now.Before(b.openUntil) {
+		return fmt.Errorf("%w until %s: %w", errCircuitOpen, b.openUntil.Format(time.RFC3339), b.lastErr)
+	}
+	return nil
+}
+
+// Record records the result of a status request for the pod.
+func (cb *circuitBreakers) Record(uid types.UID, err error, now time.Time) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if now.Sub(cb.lastPrune) > breakerTTL {
+		for k, b := range cb.m {
+			if now.Sub(b.lastSeen) > breakerTTL {
+				delete(cb.m, k)
+			}
+		}
+		cb.lastPrune = now
+	}
+
+	if err == nil {
+		delete(cb.m, uid)
+		return
+	}
+
+	b, ok := cb.m[uid]
+	if !ok {
+		b = new(circuitBreaker)
+		cb.m[uid] = b
+	}
+	b.failures++
+	b.lastErr = err
+	b.lastSeen = now
+	if b.failures < breakerThreshold {
+		return
+	}
+	if b.cooldown == 0 {
+		b.cooldown = breakerMinCooldown
+	} else {
+		b.cooldown = min(2*b.cooldown, breakerMaxCooldown)
+	}
+	b.openUntil = now.Add(b.cooldown)
+}
@@ -0,0 +1,71 @@
+package cosmos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCircuitBreakers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	boom := errors.New("boom")
+
+	t.Run("opens after threshold", func(t *testing.T) {
+		cb := newCircuitBreakers()
+		for i := 0; i < breakerThreshold-1; i++ {
+			cb.Record("1", boom, now)
+			require.NoError(t, cb.Allow("1", now))
+		}
+		cb.Record("1", boom, now)
+
+		err := cb.Allow("1", now)
+		require.ErrorIs(t, err, errCircuitOpen)
+		require.ErrorIs(t, err, boom)
+
+		// Other pods are unaffected.
+		require.NoError(t, cb.Allow("2", now))
+	})
+
+	t.Run("half open", func(t *testing.T) {
+		cb := newCircuitBreakers()
+		for i := 0; i < breakerThreshold; i++ {
+			cb.Record("1", boom, now)
+		}
+
+		// Cooldown expired; allow a trial.
+		trial := now.Add(breakerMinCooldown)
+		require.NoError(t, cb.Allow("1", trial))
+
+		// Failed trial doubles the cooldown.
+		cb.Record("1", boom, trial)
+		require.Error(t, cb.Allow("1", trial.Add(breakerMinCooldown)))
+		require.NoError(t, cb.Allow("1", trial.Add(2*breakerMinCooldown)))
+
+		// Successful trial closes the circuit.
+		cb.Record("1", nil, trial)
+		cb.Record("1", boom, trial)
+		require.NoError(t, cb.Allow("1", trial))
+	})
+
+	t.Run("max cooldown", func(t *testing.T) {
+		cb := newCircuitBreakers()
+		for i := 0; i < 100; i++ {
+			cb.Record("1", boom, now)
+		}
+		require.Error(t, cb.Allow("1", now.Add(breakerMaxCooldown-time.Second)))
+		require.NoError(t, cb.Allow("1", now.Add(breakerMaxCooldown)))
+	})
+
+	t.Run("prunes stale pods", func(t *testing.T) {
+		cb := newCircuitBreakers()
+		cb.Record("stale", boom, now)
+		cb.Record("fresh", boom, now.Add(2*breakerTTL))
+		require.Len(t, cb.m, 1)
+		require.Contains(t, cb.m, types.UID("fresh"))
+	})
+}
@@ -5,10 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"time"
+
+	"golang.org/x/net/websocket"
 )
 
 type ValidatorInfo struct {
@@ -129,3 +132,181 @@ func (client *CometClient) Status(ctx context.Context, rpcHost string) (CometSta
 	}
 	return status, err
 }
+
+// NodeDetails contains node information not included in the /status response.
+type NodeDetails struct {
+	// Application version from /abci_info.
+	AppVersion string
+	// Number of connected peers from /net_info.
+	Peers int
+	// Number of transactions in the mempool from /num_unconfirmed_txs.
+	MempoolSize int
+}
+
+// Details queries /abci_info, /net_info, and /num_unconfirmed_txs.
+func (client *CometClient) Details(ctx context.Context, rpcHost string) (NodeDetails, error) {
+	var (
+		details NodeDetails
+		abci    struct {
+			Response struct {
+				Version string `json:"version"`
+			} `json:"response"`
+		}
+		netInfo struct {
+			NPeers string `json:"n_peers"`
+		}
+		mempool struct {
+			NTxs string `json:"n_txs"`
+		}
+	)
+	if err := client.getResult(ctx, rpcHost, "abci_info", &abci); err != nil {
+		return details, fmt.Errorf("abci_info: %w", err)
+	}
+	if err := client.getResult(ctx, rpcHost, "net_info", &netInfo); err != nil {
+		return details, fmt.Errorf("net_info: %w", err)
+	}
+	if err := client.getResult(ctx, rpcHost, "num_unconfirmed_txs", &mempool); err != nil {
+		return details, fmt.Errorf("num_unconfirmed_txs: %w", err)
+	}
+	details.AppVersion = abci.Response.Version
+	details.Peers, _ = strconv.Atoi(netInfo.NPeers)
+	details.MempoolSize, _ = strconv.Atoi(mempool.NTxs)
+	return details, nil
+}
+
+// getResult decodes the JSON-RPC result of the endpoint at path into result.
+// Some chains (e.g. SEI) omit the JSON-RPC envelope, in which case the whole body is decoded.
+func (client *CometClient) getResult(ctx context.Context, rpcHost, path string, result any) error {
+	u, err := url.ParseRequestURI(rpcHost)
+	if err != nil {
+		return fmt.Errorf("malformed host: %w", err)
+	}
+	u.Path = path
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("malformed request: %w", err)
+	}
+	resp, err := client.httpDo(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var envelope struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err = json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
+	}
+	if len(envelope.Result) > 0 {
+		body = envelope.Result
+	}
+	if err = json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("malformed json: %w", err)
+	}
+	return nil
+}
+
+// Block is the subset of a CometBFT NewBlock event used to track chain progress.
+type Block struct {
+	Height uint64
+	Hash   string
+	Time   time.Time
+}
+
+// rpcNewBlockEvent is a JSON-RPC message received on a tm.event='NewBlock' subscription.
+type rpcNewBlockEvent struct {
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+	Result struct {
+		Data struct {
+			Value struct {
+				Block struct {
+					Header struct {
+						Height string    `json:"height"`
+						Time   time.Time `json:"time"`
+					} `json:"header"`
+				} `json:"block"`
+				BlockID struct {
+					Hash string `json:"hash"`
+				} `json:"block_id"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+}
+
+// blockReadTimeout bounds how long to wait for the next block before treating the connection as dead.
+const blockReadTimeout = time.Minute
+
+// SubscribeNewBlocks subscribes to tm.event='NewBlock' on the /websocket endpoint and calls onBlock for every block.
+// It blocks until the context is cancelled or the connection fails, and always returns a non-nil error.
+func (client *CometClient) SubscribeNewBlocks(ctx context.Context, rpcHost string, onBlock func(Block)) error {
+	u, err := url.ParseRequestURI(rpcHost)
+	if err != nil {
+		return fmt.Errorf("malformed host: %w", err)
+	}
+	origin := u.String()
+	if u.Scheme == "https" {
+		u.Scheme = "wss"
+	} else {
+		u.Scheme = "ws"
+	}
+	u.Path = "websocket"
+
+	cfg, err := websocket.NewConfig(u.String(), origin)
+	if err != nil {
+		return fmt.Errorf("websocket config: %w", err)
+	}
+	conn, err := cfg.DialContext(ctx)
+	if err != nil {
+		return fmt.Errorf("websocket dial: %w", err)
+	}
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	subscribe := map[string]any{
+		"jsonrpc": "2.0",
+		"method":  "subscribe",
+		"id":      1,
+		"params":  map[string]string{"query": "tm.event='NewBlock'"},
+	}
+	if err = websocket.JSON.Send(conn, subscribe); err != nil {
+		return fmt.Errorf("subscribe: %w", err)
+	}
+
+	for {
+		if err = conn.SetReadDeadline(time.Now().Add(blockReadTimeout)); err != nil {
+			return err
+		}
+		var event rpcNewBlockEvent
+		if err = websocket.JSON.Receive(conn, &event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("receive: %w", err)
+		}
+		if event.Error != nil {
+			return fmt.Errorf("subscribe: %s: %s", event.Error.Message, event.Error.Data)
+		}
+		header := event.Result.Data.Value.Block.Header
+		// The subscription acknowledgement has an empty result.
+		if header.Height == "" {
+			continue
+		}
+		height, err := strconv.ParseUint(header.Height, 10, 64)
+		if err != nil {
+			return fmt.Errorf("malformed height %q: %w", header.Height, err)
+		}
+		onBlock(Block{Height: height, Hash: event.Result.Data.Value.BlockID.Hash, Time: header.Time})
+	}
+}
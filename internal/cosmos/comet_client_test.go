@@ -3,12 +3,16 @@ package cosmos
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 )
 
 func TestCometStatus_LatestBlockHeight(t *testing.T) {
@@ -119,6 +123,122 @@ func TestCometClient_Status(t *testing.T) {
 	})
 }
 
+func TestCometClient_Details(t *testing.T) {
+	t.Parallel()
+
+	const (
+		abciInfo    = `{"jsonrpc":"2.0","id":-1,"result":{"response":{"data":"GaiaApp","version":"v15.0.0","last_block_height":"100"}}}`
+		netInfo     = `{"jsonrpc":"2.0","id":-1,"result":{"listening":true,"n_peers":"25","peers":[]}}`
+		unconfirmed = `{"n_txs":"7","total":"7","total_bytes":"1024"}` // SEI-style response without the envelope.
+	)
+
+	t.Run("happy path", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			var body string
+			switch req.URL.String() {
+			case "http://10.2.3.4:26657/abci_info":
+				body = abciInfo
+			case "http://10.2.3.4:26657/net_info":
+				body = netInfo
+			case "http://10.2.3.4:26657/num_unconfirmed_txs":
+				body = unconfirmed
+			default:
+				panic("unexpected url " + req.URL.String())
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+
+		got, err := client.Details(context.Background(), "http://10.2.3.4:26657")
+		require.NoError(t, err)
+		require.Equal(t, NodeDetails{AppVersion: "v15.0.0", Peers: 25, MempoolSize: 7}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		client.httpDo = func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/net_info" {
+				return nil, errors.New("boom")
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(abciInfo))}, nil
+		}
+
+		_, err := client.Details(context.Background(), "http://10.2.3.4:26657")
+		require.EqualError(t, err, "net_info: boom")
+	})
+}
+
+func TestCometClient_SubscribeNewBlocks(t *testing.T) {
+	t.Parallel()
+
+	const blockEvent = `{"jsonrpc":"2.0","id":1,"result":{"query":"tm.event='NewBlock'","data":{"type":"tendermint/event/NewBlock","value":{"block":{"header":{"height":"%d","time":"2023-08-01T12:00:00Z"}},"block_id":{"hash":"ABC%d"}}}}}`
+
+	t.Run("happy path", func(t *testing.T) {
+		var gotReq map[string]any
+		srv := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+			_ = websocket.JSON.Receive(conn, &gotReq)
+			// Acknowledgement
+			_ = websocket.Message.Send(conn, `{"jsonrpc":"2.0","id":1,"result":{}}`)
+			for h := 10; h < 12; h++ {
+				_ = websocket.Message.Send(conn, fmt.Sprintf(blockEvent, h, h))
+			}
+		}))
+		defer srv.Close()
+
+		var got []Block
+		client := NewCometClient(http.DefaultClient)
+		err := client.SubscribeNewBlocks(context.Background(), srv.URL, func(block Block) {
+			got = append(got, block)
+		})
+		require.Error(t, err)
+
+		require.Equal(t, "subscribe", gotReq["method"])
+		require.Equal(t, map[string]any{"query": "tm.event='NewBlock'"}, gotReq["params"])
+
+		ts := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+		require.Equal(t, []Block{
+			{Height: 10, Hash: "ABC10", Time: ts},
+			{Height: 11, Hash: "ABC11", Time: ts},
+		}, got)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		srv := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+			var req map[string]any
+			_ = websocket.JSON.Receive(conn, &req)
+			_, _ = io.Copy(io.Discard, conn)
+		}))
+		defer srv.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		client := NewCometClient(http.DefaultClient)
+		err := client.SubscribeNewBlocks(ctx, srv.URL, func(Block) { panic("should not be called") })
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("subscribe error", func(t *testing.T) {
+		srv := httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+			var req map[string]any
+			_ = websocket.JSON.Receive(conn, &req)
+			_ = websocket.Message.Send(conn, `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Internal error","data":"max_subscriptions_per_client reached"}}`)
+		}))
+		defer srv.Close()
+
+		client := NewCometClient(http.DefaultClient)
+		err := client.SubscribeNewBlocks(context.Background(), srv.URL, func(Block) { panic("should not be called") })
+		require.EqualError(t, err, "subscribe: Internal error: max_subscriptions_per_client reached")
+	})
+
+	t.Run("dial error", func(t *testing.T) {
+		client := NewCometClient(http.DefaultClient)
+		err := client.SubscribeNewBlocks(context.Background(), "http://127.0.0.1:1", func(Block) {})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "websocket dial")
+	})
+}
+
 const statusResponseFixture = `
 {
   "jsonrpc": "2.0",
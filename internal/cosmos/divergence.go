@@ -0,0 +1,192 @@
+package cosmos
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// HashKind identifies a hash compared across pods.
+type HashKind string
+
+const (
+	BlockHash HashKind = "BlockHash"
+	AppHash   HashKind = "AppHash"
+)
+
+// Divergence describes a pod that reported a different hash than the majority of pods on the same chain
+// at the same height.
+type Divergence struct {
+	ChainID string
+	Height  uint64
+	Kind    HashKind
+	// The hash reported by the pod.
+	Hash string
+	// The hash reported by the majority.
+	Expected string
+	// Number of pods that reported Expected.
+	Agree int
+	// Number of pods that reported a hash at Height.
+	Total int
+	// When the divergence was detected.
+	DetectedAt time.Time
+}
+
+// Heights, and divergences of pods, not seen within this duration are forgotten.
+const hashTTL = 10 * time.Minute
+
+// hashTracker records block and app hashes per height for every pod of every chain, across all controllers.
+// A pod that reports a hash different from a strict majority at the same height is diverged until the pod is gone.
+type hashTracker struct {
+	mu        sync.Mutex
+	chains    map[string]map[uint64]*heightHashes
+	diverged  map[types.UID]*divergedPod
+	lastPrune time.Time
+}
+
+type heightHashes struct {
+	seen   time.Time
+	hashes map[HashKind]map[types.UID]string
+}
+
+type divergedPod struct {
+	Divergence
+	lastSeen time.Time
+}
+
+func newHashTracker() *hashTracker {
+	return &hashTracker{
+		chains:   make(map[string]map[uint64]*heightHashes),
+		diverged: make(map[types.UID]*divergedPod),
+	}
+}
+
+// Observe records the hashes of each successfully collected item and detects divergences.
+func (ht *hashTracker) Observe(coll StatusCollection, now time.Time) {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+
+	ht.prune(now)
+
+	for _, item := range coll {
+		uid := item.GetPod().UID
+		if d := ht.diverged[uid]; d != nil {
+			d.lastSeen = now
+		}
+		if item.Err != nil {
+			continue
+		}
+		var (
+			chainID = item.Status.Result.NodeInfo.Network
+			height  = item.Status.LatestBlockHeight()
+			info    = item.Status.Result.SyncInfo
+		)
+		if chainID == "" || height == 0 {
+			continue
+		}
+		heights := ht.chains[chainID]
+		if heights == nil {
+			heights = make(map[uint64]*heightHashes)
+			ht.chains[chainID] = heights
+		}
+		hh := heights[height]
+		if hh == nil {
+			hh = &heightHashes{seen: now, hashes: make(map[HashKind]map[types.UID]string)}
+			heights[height] = hh
+		}
+		ht.record(hh, BlockHash, uid, info.LatestBlockHash)
+		ht.record(hh, AppHash, uid, info.LatestAppHash)
+		ht.detect(chainID, height, hh, now)
+	}
+}
+
+// Divergence returns the pod's divergence or nil if the pod has not diverged.
+func (ht *hashTracker) Divergence(uid types.UID) *Divergence {
+	ht.mu.Lock()
+	defer ht.mu.Unlock()
+	d := ht.diverged[uid]
+	if d == nil {
+		return nil
+	}
+	div := d.Divergence
+	return &div
+}
+
+func (ht *hashTracker) record(hh *heightHashes, kind HashKind, uid types.UID, hash string) {
+	if hash == "" {
+		return
+	}
+	m := hh.hashes[kind]
+	if m == nil {
+		m = make(map[types.UID]string)
+		hh.hashes[kind] = m
+	}
+	m[uid] = hash
+}
+
+func (ht *hashTracker) detect(chainID string, height uint64, hh *heightHashes, now time.Time) {
+	for _, kind := range []HashKind{BlockHash, AppHash} {
+		reports := hh.hashes[kind]
+		if len(reports) < 2 {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, hash := range reports {
+			counts[hash]++
+		}
+		var (
+			majority string
+			most     int
+		)
+		for hash, n := range counts {
+			if n > most {
+				majority, most = hash, n
+			}
+		}
+		// Without a strict majority, there is no way to tell which pods are correct.
+		if most*2 <= len(reports) {
+			continue
+		}
+		for uid, hash := range reports {
+			if hash == majority || ht.diverged[uid] != nil {
+				continue
+			}
+			ht.diverged[uid] = &divergedPod{
+				Divergence: Divergence{
+					ChainID:    chainID,
+					Height:     height,
+					Kind:       kind,
+					Hash:       hash,
+					Expected:   majority,
+					Agree:      most,
+					Total:      len(reports),
+					DetectedAt: now,
+				},
+				lastSeen: now,
+			}
+		}
+	}
+}
+
+func (ht *hashTracker) prune(now time.Time) {
+	if now.Sub(ht.lastPrune) < time.Minute {
+		return
+	}
+	ht.lastPrune = now
+	for chainID, heights := range ht.chains {
+		for height, hh := range heights {
+			if now.Sub(hh.seen) > hashTTL {
+				delete(heights, height)
+			}
+		}
+		if len(heights) == 0 {
+			delete(ht.chains, chainID)
+		}
+	}
+	for uid, d := range ht.diverged {
+		if now.Sub(d.lastSeen) > hashTTL {
+			delete(ht.diverged, uid)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cosmos
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHashTracker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	item := func(uid, chainID, height, blockHash, appHash string) StatusItem {
+		var pod corev1.Pod
+		pod.UID = types.UID(uid)
+		var status CometStatus
+		status.Result.NodeInfo.Network = chainID
+		status.Result.SyncInfo.LatestBlockHeight = height
+		status.Result.SyncInfo.LatestBlockHash = blockHash
+		status.Result.SyncInfo.LatestAppHash = appHash
+		return StatusItem{Pod: &pod, Status: status}
+	}
+
+	t.Run("app hash", func(t *testing.T) {
+		ht := newHashTracker()
+		// Observed by different controllers for the same chain.
+		ht.Observe(StatusCollection{item("a", "osmosis-1", "100", "B1", "A1"), item("b", "osmosis-1", "100", "B1", "A1")}, now)
+		ht.Observe(StatusCollection{item("c", "osmosis-1", "100", "B1", "BAD")}, now)
+
+		require.Nil(t, ht.Divergence("a"))
+		require.Nil(t, ht.Divergence("b"))
+
+		got := ht.Divergence("c")
+		require.NotNil(t, got)
+		want := Divergence{
+			ChainID: "osmosis-1", Height: 100, Kind: AppHash, Hash: "BAD", Expected: "A1", Agree: 2, Total: 3, DetectedAt: now,
+		}
+		require.Equal(t, want, *got)
+
+		// Sticky once diverged.
+		ht.Observe(StatusCollection{item("c", "osmosis-1", "101", "B2", "A2")}, now.Add(time.Second))
+		require.NotNil(t, ht.Divergence("c"))
+	})
+
+	t.Run("block hash", func(t *testing.T) {
+		ht := newHashTracker()
+		ht.Observe(StatusCollection{
+			item("a", "osmosis-1", "100", "B1", ""),
+			item("b", "osmosis-1", "100", "B1", ""),
+			item("c", "osmosis-1", "100", "FORK", ""),
+		}, now)
+
+		got := ht.Divergence("c")
+		require.NotNil(t, got)
+		require.Equal(t, BlockHash, got.Kind)
+		require.Equal(t, "B1", got.Expected)
+	})
+
+	t.Run("no divergence", func(t *testing.T) {
+		for _, tt := range []struct {
+			Name string
+			Coll StatusCollection
+		}{
+			{"no majority", StatusCollection{item("a", "osmosis-1", "100", "B1", "A1"), item("b", "osmosis-1", "100", "B1", "A2")}},
+			{"different heights", StatusCollection{item("a", "osmosis-1", "100", "B1", "A1"), item("b", "osmosis-1", "101", "B2", "A2"), item("c", "osmosis-1", "101", "B2", "A2")}},
+			{"different chains", StatusCollection{item("a", "osmosis-1", "100", "B1", "A1"), item("b", "osmo-test-5", "100", "B2", "A2"), item("c", "osmo-test-5", "100", "B2", "A2")}},
+			{"missing hashes", StatusCollection{item("a", "osmosis-1", "100", "", ""), item("b", "osmosis-1", "100", "B1", "A1"), item("c", "osmosis-1", "100", "B1", "A1")}},
+		} {
+			ht := newHashTracker()
+			ht.Observe(tt.Coll, now)
+			for _, item := range tt.Coll {
+				require.Nil(t, ht.Divergence(item.GetPod().UID), tt.Name)
+			}
+		}
+	})
+
+	t.Run("ignores errors", func(t *testing.T) {
+		ht := newHashTracker()
+		bad := item("c", "osmosis-1", "100", "B1", "BAD")
+		bad.Err = errors.New("boom")
+		ht.Observe(StatusCollection{item("a", "osmosis-1", "100", "B1", "A1"), item("b", "osmosis-1", "100", "B1", "A1"), bad}, now)
+		require.Nil(t, ht.Divergence("c"))
+	})
+
+	t.Run("prunes", func(t *testing.T) {
+		ht := newHashTracker()
+		ht.Observe(StatusCollection{
+			item("a", "osmosis-1", "100", "B1", "A1"),
+			item("b", "osmosis-1", "100", "B1", "A1"),
+			item("c", "osmosis-1", "100", "B1", "BAD"),
+		}, now)
+		require.NotNil(t, ht.Divergence("c"))
+
+		later := now.Add(hashTTL + time.Minute)
+		ht.Observe(StatusCollection{item("a", "osmosis-1", "200", "B2", "A2")}, later)
+		require.Nil(t, ht.Divergence("c"))
+		require.Len(t, ht.chains["osmosis-1"], 1)
+	})
+}
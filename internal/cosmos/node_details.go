@@ -0,0 +1,76 @@
+package cosmos
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DetailsFetcher fetches node information not included in the /status response.
+// A Statuser that also implements DetailsFetcher has details attached to each StatusItem.
+type DetailsFetcher interface {
+	Details(ctx context.Context, rpcHost string) (NodeDetails, error)
+}
+
+const (
+	// Details change slowly, so they are fetched less often than status to limit load on nodes.
+	detailsInterval = 30 * time.Second
+	// Details for pods not seen within this duration are forgotten.
+	detailsTTL = 10 * time.Minute
+)
+
+// detailsCache remembers the last NodeDetails per pod so they are refreshed at most every detailsInterval.
+type detailsCache struct {
+	mu        sync.Mutex
+	m         map[types.UID]*detailsEntry
+	lastPrune time.Time
+}
+
+type detailsEntry struct {
+	details  *NodeDetails
+	fetched  time.Time
+	lastSeen time.Time
+}
+
+func newDetailsCache() *detailsCache {
+	return &detailsCache{m: make(map[types.UID]*detailsEntry)}
+}
+
+// Get returns the pod's details, fetching them if stale. If fetching fails, the previous details are returned, if any.
+func (dc *detailsCache) Get(ctx context.Context, fetcher DetailsFetcher, uid types.UID, rpcHost string, now time.Time) *NodeDetails {
+	dc.mu.Lock()
+	if now.Sub(dc.lastPrune) > detailsTTL {
+		for k, e := range dc.m {
+			if now.Sub(e.lastSeen) > detailsTTL {
+				delete(dc.m, k)
+			}
+		}
+		dc.lastPrune = now
+	}
+	entry, ok := dc.m[uid]
+	if !ok {
+		entry = new(detailsEntry)
+		dc.m[uid] = entry
+	}
+	entry.lastSeen = now
+	prev := entry.details
+	fresh := prev != nil && now.Sub(entry.fetched) < detailsInterval
+	dc.mu.Unlock()
+
+	if fresh {
+		return prev
+	}
+
+	details, err := fetcher.Details(ctx, rpcHost)
+	if err != nil {
+		return prev
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	entry.details = &details
+	entry.fetched = now
+	return &details
+}
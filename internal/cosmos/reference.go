@@ -0,0 +1,153 @@
+package cosmos
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultReferenceMaxLag is the default number of blocks a pod may lag the reference height and still be
+// considered in sync.
+const DefaultReferenceMaxLag = 10
+
+// referenceState is a CosmosFullNode's reference RPCs and the last chain tip they reported.
+type referenceState struct {
+	urls      []string
+	fullNodes []client.ObjectKey
+	maxLag    uint64
+
+	height     uint64
+	observedAt time.Time
+}
+
+// ReferenceMaxLag returns spec.selfHeal.referenceMaxLag or its default.
+func ReferenceMaxLag(crd *cosmosv1.CosmosFullNode) uint64 {
+	if spec := crd.Spec.SelfHeal; spec != nil && spec.ReferenceMaxLag != nil {
+		return uint64(*spec.ReferenceMaxLag)
+	}
+	return DefaultReferenceMaxLag
+}
+
+// SetReferences updates the reference RPCs from crd's spec. Clears the reference tip if the references changed.
+func (c *cache) SetReferences(key client.ObjectKey, crd *cosmosv1.CosmosFullNode) {
+	next := referenceState{maxLag: ReferenceMaxLag(crd)}
+	if spec := crd.Spec.SelfHeal; spec != nil {
+		for _, ref := range spec.ReferenceRPCs {
+			switch {
+			case ref.URL != "":
+				next.urls = append(next.urls, ref.URL)
+			case ref.CosmosFullNode != nil:
+				ns := ref.CosmosFullNode.Namespace
+				if ns == "" {
+					ns = crd.Namespace
+				}
+				next.fullNodes = append(next.fullNodes, client.ObjectKey{Namespace: ns, Name: ref.CosmosFullNode.Name})
+			}
+		}
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	v, ok := c.m[key]
+	if !ok {
+		return
+	}
+	prev := v.reference
+	if slices.Equal(prev.urls, next.urls) && slices.Equal(prev.fullNodes, next.fullNodes) {
+		next.height, next.observedAt = prev.height, prev.observedAt
+	}
+	v.reference = next
+}
+
+// ReferenceTip returns the controller's reference tip if observed within ttl.
+func (c *cache) ReferenceTip(key client.ObjectKey, now time.Time, ttl time.Duration) (ReferenceTip, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	v, ok := c.m[key]
+	if !ok || v.reference.height == 0 || now.Sub(v.reference.observedAt) > ttl {
+		return ReferenceTip{}, false
+	}
+	return ReferenceTip{Height: v.reference.height, MaxLag: v.reference.maxLag}, true
+}
+
+func (c *cache) references(key client.ObjectKey) referenceState {
+	c.RLock()
+	defer c.RUnlock()
+	if v, ok := c.m[key]; ok {
+		return v.reference
+	}
+	return referenceState{}
+}
+
+func (c *cache) setReferenceHeight(key client.ObjectKey, height uint64, ts time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	if v, ok := c.m[key]; ok {
+		v.reference.height = height
+		v.reference.observedAt = ts
+	}
+}
+
+// PollReferences polls each CosmosFullNode's spec.selfHeal.referenceRPCs URLs to establish the chain tip.
+// References to other CosmosFullNodes use the cached height of their pods and do not require this.
+// Must be called before SetupWithManager.
+func (c *CacheController) PollReferences(comet Statuser) {
+	c.reference = comet
+}
+
+// pollReferencesLoop polls the controller's references every referenceInterval until ctx is canceled.
+// It runs apart from pod status collection so slow references never delay it.
+func (c *CacheController) pollReferencesLoop(ctx context.Context, controller client.ObjectKey) {
+	ticker := time.NewTicker(c.referenceInterval)
+	defer ticker.Stop()
+	for {
+		// The first poll is immediate.
+		c.pollReferences(ctx, controller)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollReferences sets the controller's reference tip to the max height reported by its references.
+// Reference URLs are queried in parallel under a single referenceTimeout.
+// Unreachable references are ignored. The tip is unchanged if no reference reported a height.
+func (c *CacheController) pollReferences(ctx context.Context, controller client.ObjectKey) {
+	refs := c.cache.references(controller)
+	heights := make([]uint64, len(refs.urls))
+	if c.reference != nil && len(refs.urls) > 0 {
+		cctx, cancel := context.WithTimeout(ctx, c.referenceTimeout)
+		var eg errgroup.Group
+		for i, url := range refs.urls {
+			eg.Go(func() error {
+				if status, err := c.reference.Status(cctx, url); err == nil {
+					heights[i] = status.LatestBlockHeight()
+				}
+				return nil
+			})
+		}
+		_ = eg.Wait()
+		cancel()
+	}
+	var height uint64
+	if len(heights) > 0 {
+		height = slices.Max(heights)
+	}
+	for _, key := range refs.fullNodes {
+		coll, _ := c.cache.Get(key)
+		for _, item := range coll {
+			if item.Err == nil {
+				height = max(height, item.Status.LatestBlockHeight())
+			}
+		}
+	}
+	if height > 0 {
+		c.cache.setReferenceHeight(controller, height, time.Now())
+	}
+}
@@ -0,0 +1,156 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestReferenceMaxLag(t *testing.T) {
+	t.Parallel()
+
+	var crd cosmosv1.CosmosFullNode
+	require.EqualValues(t, DefaultReferenceMaxLag, ReferenceMaxLag(&crd))
+
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{ReferenceMaxLag: lo.ToPtr(int32(3))}
+	require.EqualValues(t, 3, ReferenceMaxLag(&crd))
+}
+
+func TestCacheController_PollReferences(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "default", Name: "osmosis"}
+	otherKey := client.ObjectKey{Namespace: "other", Name: "osmosis-archive"}
+
+	newCRD := func() *cosmosv1.CosmosFullNode {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = key.Name
+		crd.Namespace = key.Namespace
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			ReferenceMaxLag: lo.ToPtr(int32(5)),
+			ReferenceRPCs: []cosmosv1.ReferenceRPC{
+				{URL: "http://rpc1.example.com:26657"},
+				{URL: "http://rpc2.example.com:26657"},
+				{CosmosFullNode: &cosmosv1.ReferenceFullNode{Name: otherKey.Name, Namespace: otherKey.Namespace}},
+			},
+		}
+		return &crd
+	}
+
+	newStatus := func(height string) CometStatus {
+		var status CometStatus
+		status.Result.SyncInfo.LatestBlockHeight = height
+		return status
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		var (
+			reader    mockReader
+			collector mockCollector
+		)
+		pod := corev1.Pod{}
+		pod.UID = types.UID("1")
+		reader.ListPods = []corev1.Pod{pod}
+
+		controller := NewCacheController(&collector, &reader, nil)
+		defer func() { _ = controller.Close() }()
+
+		var (
+			mu        sync.Mutex
+			gotHosts  []string
+			deadlines []time.Time
+			started   sync.WaitGroup
+		)
+		started.Add(2)
+		controller.PollReferences(mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			mu.Lock()
+			gotHosts = append(gotHosts, rpcHost)
+			deadlines = append(deadlines, deadline)
+			mu.Unlock()
+			// Blocks until both references are queried, so queries must run in parallel.
+			started.Done()
+			started.Wait()
+			if rpcHost == "http://rpc2.example.com:26657" {
+				return CometStatus{}, errors.New("boom")
+			}
+			return newStatus("100"), nil
+		}))
+
+		controller.cache.Init(key, func() {})
+		controller.cache.SetReferences(key, newCRD())
+
+		controller.cache.Init(otherKey, func() {})
+		controller.cache.Update(otherKey, StatusCollection{
+			{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "2"}}, Status: newStatus("110")},
+			{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "3"}}, Status: newStatus("999"), Err: errors.New("stale")},
+		})
+
+		_, ok := controller.cache.ReferenceTip(key, time.Now(), time.Minute)
+		require.False(t, ok)
+
+		controller.pollReferences(ctx, key)
+
+		require.ElementsMatch(t, []string{"http://rpc1.example.com:26657", "http://rpc2.example.com:26657"}, gotHosts)
+		require.Len(t, deadlines, 2)
+		require.Equal(t, deadlines[0], deadlines[1])
+
+		tip, ok := controller.cache.ReferenceTip(key, time.Now(), time.Minute)
+		require.True(t, ok)
+		require.Equal(t, ReferenceTip{Height: 110, MaxLag: 5}, tip)
+
+		_, ok = controller.cache.ReferenceTip(key, time.Now().Add(2*time.Minute), time.Minute)
+		require.False(t, ok)
+
+		coll := controller.Collect(ctx, key)
+		require.Len(t, coll, 1)
+		require.Equal(t, &ReferenceTip{Height: 110, MaxLag: 5}, coll[0].Reference)
+
+		// Unchanged references keep the tip.
+		controller.cache.SetReferences(key, newCRD())
+		_, ok = controller.cache.ReferenceTip(key, time.Now(), time.Minute)
+		require.True(t, ok)
+
+		// Changed references clear the tip.
+		crd := newCRD()
+		crd.Spec.SelfHeal.ReferenceRPCs = crd.Spec.SelfHeal.ReferenceRPCs[:1]
+		controller.cache.SetReferences(key, crd)
+		_, ok = controller.cache.ReferenceTip(key, time.Now(), time.Minute)
+		require.False(t, ok)
+	})
+
+	t.Run("no heights", func(t *testing.T) {
+		var (
+			reader    mockReader
+			collector mockCollector
+		)
+		controller := NewCacheController(&collector, &reader, nil)
+		defer func() { _ = controller.Close() }()
+
+		controller.PollReferences(mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+			return CometStatus{}, errors.New("boom")
+		}))
+
+		controller.cache.Init(key, func() {})
+		controller.cache.SetReferences(key, newCRD())
+		controller.pollReferences(ctx, key)
+
+		_, ok := controller.cache.ReferenceTip(key, time.Now(), time.Minute)
+		require.False(t, ok)
+
+		coll := controller.Collect(ctx, key)
+		require.True(t, lo.EveryBy(coll, func(item StatusItem) bool { return item.Reference == nil }))
+	})
+}
@@ -15,8 +15,36 @@ import (
 type StatusItem struct {
 	Pod    *corev1.Pod
 	Status CometStatus
-	TS     time.Time
-	Err    error
+	// Details is nil if unavailable.
+	Details *NodeDetails
+	// SyncRate is nil until enough height history is collected.
+	SyncRate *SyncRate
+	// When the pod's height last increased or was first observed. Zero if the pod has no height history.
+	HeightChangedAt time.Time
+	// The last block height and time successfully observed, even if the latest status failed. Nil if never observed.
+	LastBlock *Block
+	// Set if the pod reported a different block or app hash than the majority of pods on the same chain.
+	Divergence *Divergence
+	// The chain tip according to the CosmosFullNode's reference RPCs. Nil if not configured or unknown.
+	Reference *ReferenceTip
+	TS        time.Time
+	Err       error
+}
+
+// ReferenceTip is the chain tip according to reference RPCs.
+type ReferenceTip struct {
+	// The max height reported by the references.
+	Height uint64
+	// The maximum number of blocks a pod may lag Height and still be considered in sync.
+	MaxLag uint64
+}
+
+// BehindReference returns true if the pod's height lags the reference height by more than the max lag.
+func (status StatusItem) BehindReference() bool {
+	if status.Reference == nil || status.Err != nil {
+		return false
+	}
+	return status.Status.LatestBlockHeight()+status.Reference.MaxLag < status.Reference.Height
 }
 
 // GetPod returns the pod.
@@ -95,7 +123,20 @@ func (coll StatusCollection) Pods() []*corev1.Pod {
 }
 
 // Synced returns all items that are caught up with the chain tip.
+// Items that lag the reference height are not synced even if they report themselves as caught up.
 func (coll StatusCollection) Synced() StatusCollection {
+	var items []StatusItem
+	for _, status := range coll.ReportedSynced() {
+		if status.BehindReference() {
+			continue
+		}
+		items = append(items, status)
+	}
+	return items
+}
+
+// ReportedSynced returns all items that report themselves as caught up, regardless of the reference height.
+func (coll StatusCollection) ReportedSynced() StatusCollection {
 	var items []StatusItem
 	for _, status := range coll {
 		if status.Err != nil {
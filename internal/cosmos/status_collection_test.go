@@ -5,8 +5,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestStatusCollection_Synced(t *testing.T) {
@@ -34,6 +36,23 @@ func TestStatusCollection_Synced(t *testing.T) {
 	require.Len(t, coll.Synced(), 1)
 	require.Len(t, coll.SyncedPods(), 1)
 	require.Equal(t, "in-sync", coll.SyncedPods()[0].Name)
+
+	t.Run("reference", func(t *testing.T) {
+		var lagging StatusItem
+		lagging.Pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "lagging"}}
+		lagging.Status.Result.SyncInfo.LatestBlockHeight = "89"
+		lagging.Reference = &ReferenceTip{Height: 100, MaxLag: 10}
+
+		within := lagging
+		within.Pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "within"}}
+		within.Status.Result.SyncInfo.LatestBlockHeight = "90"
+
+		coll := StatusCollection{lagging, within}
+		require.True(t, lagging.BehindReference())
+		require.False(t, within.BehindReference())
+		require.Equal(t, []string{"within"}, lo.Map(coll.SyncedPods(), func(pod *corev1.Pod, _ int) string { return pod.Name }))
+		require.Len(t, coll.ReportedSynced(), 2)
+	})
 }
 
 func TestUpsertPod(t *testing.T) {
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"golang.org/x/sync/errgroup"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -17,20 +18,34 @@ type Statuser interface {
 }
 
 // StatusCollector collects the CometBFT status of all pods owned by a controller.
+// A single StatusCollector is shared by all controllers, so its in-flight limit and circuit breakers are global.
 type StatusCollector struct {
-	comet   Statuser
-	timeout time.Duration
+	comet    Statuser
+	timeout  time.Duration
+	inFlight chan struct{}
+	breakers *circuitBreakers
+	details  *detailsCache
 }
 
 // NewStatusCollector returns a valid StatusCollector.
 // Timeout is exposed here because it is important for good performance in reconcile loops,
 // and reminds callers to set it.
-func NewStatusCollector(comet Statuser, timeout time.Duration) *StatusCollector {
-	return &StatusCollector{comet: comet, timeout: timeout}
+// MaxInFlight limits concurrent RPC requests across all calls to Collect and must be > 0.
+func NewStatusCollector(comet Statuser, timeout time.Duration, maxInFlight int) *StatusCollector {
+	return &StatusCollector{
+		comet:    comet,
+		timeout:  timeout,
+		inFlight: make(chan struct{}, maxInFlight),
+		breakers: newCircuitBreakers(),
+		details:  newDetailsCache(),
+	}
 }
 
 // Collect returns a StatusCollection for the given pods.
-// Any non-nil error can be treated as transient and retried.
+// Any non-nil error can be treated as transient and retried, except a *WrongNetworkError if the node's network
+// does not match the pod's chain ID annotation.
+// If the Statuser is also a DetailsFetcher, each successful item includes NodeDetails refreshed at most every 30s.
+// Pods that repeatedly fail are skipped with an error until their circuit breaker allows a retry.
 func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) StatusCollection {
 	var eg errgroup.Group
 	now := time.Now()
@@ -41,33 +56,43 @@ func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) Stat
 			pod := pods[i]
 			statuses[i].TS = now
 			statuses[i].Pod = &pod
-			ip := pod.Status.PodIP
-			if ip == "" {
+			if pod.Status.PodIP == "" {
 				// Check for IP, so we don't pay overhead of making a request.
 				statuses[i].Err = errors.New("pod has no IP")
 				return nil
 			}
-			var rpcPort int32 = 26657
-			for _, c := range pod.Spec.Containers {
-				if c.Name == "node" {
-					for _, p := range c.Ports {
-						if p.Name == "rpc" {
-							rpcPort = p.ContainerPort
-							break
-						}
-					}
-					break
-				}
+			if err := coll.breakers.Allow(pod.UID, now); err != nil {
+				statuses[i].Err = err
+				return nil
+			}
+
+			select {
+			case coll.inFlight <- struct{}{}:
+				defer func() { <-coll.inFlight }()
+			case <-ctx.Done():
+				statuses[i].Err = ctx.Err()
+				return nil
 			}
-			host := fmt.Sprintf("http://%s:%d", ip, rpcPort)
+
+			host := rpcHost(&pod)
 			cctx, cancel := context.WithTimeout(ctx, coll.timeout)
 			defer cancel()
 			resp, err := coll.comet.Status(cctx, host)
+			if ctx.Err() == nil {
+				coll.breakers.Record(pod.UID, err, time.Now())
+			}
 			if err != nil {
 				statuses[i].Err = err
 				return nil
 			}
+			if want := pod.Annotations[kube.ChainIDAnnotation]; want != "" && resp.Result.NodeInfo.Network != want {
+				statuses[i].Err = &WrongNetworkError{Want: want, Got: resp.Result.NodeInfo.Network}
+				return nil
+			}
 			statuses[i].Status = resp
+			if fetcher, ok := coll.comet.(DetailsFetcher); ok {
+				statuses[i].Details = coll.details.Get(cctx, fetcher, pod.UID, host, now)
+			}
 			return nil
 		})
 	}
@@ -76,3 +101,30 @@ func (coll StatusCollector) Collect(ctx context.Context, pods []corev1.Pod) Stat
 	sort.Sort(statuses)
 	return statuses
 }
+
+// WrongNetworkError is the error of a StatusItem whose node reports a network other than its expected chain ID.
+type WrongNetworkError struct {
+	Want string
+	Got  string
+}
+
+func (e *WrongNetworkError) Error() string {
+	return fmt.Sprintf("wrong network: node reports chain ID %q, expected %q", e.Got, e.Want)
+}
+
+// rpcHost returns the CometBFT RPC address of the pod's node container.
+func rpcHost(pod *corev1.Pod) string {
+	var rpcPort int32 = 26657
+	for _, c := range pod.Spec.Containers {
+		if c.Name == "node" {
+			for _, p := range c.Ports {
+				if p.Name == "rpc" {
+					rpcPort = p.ContainerPort
+					break
+				}
+			}
+			break
+		}
+	}
+	return fmt.Sprintf("http://%s:%d", pod.Status.PodIP, rpcPort)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -20,6 +21,15 @@ func (fn mockStatuser) Status(ctx context.Context, rpcHost string) (CometStatus,
 	return fn(ctx, rpcHost)
 }
 
+type mockDetailsStatuser struct {
+	mockStatuser
+	DetailsFn func(ctx context.Context, rpcHost string) (NodeDetails, error)
+}
+
+func (m mockDetailsStatuser) Details(ctx context.Context, rpcHost string) (NodeDetails, error) {
+	return m.DetailsFn(ctx, rpcHost)
+}
+
 var panicStatuser = mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
 	panic("should not be called")
 })
@@ -58,7 +68,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 			return status, nil
 		})
 
-		coll := NewStatusCollector(cometClient, timeout)
+		coll := NewStatusCollector(cometClient, timeout, 10)
 		got := coll.Collect(ctx, pods)
 
 		require.Len(t, got, 3)
@@ -79,7 +89,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 	})
 
 	t.Run("no pod IP", func(t *testing.T) {
-		coll := NewStatusCollector(panicStatuser, timeout)
+		coll := NewStatusCollector(panicStatuser, timeout, 10)
 		got := coll.Collect(ctx, make([]corev1.Pod, 1))
 
 		require.Len(t, got, 1)
@@ -94,7 +104,7 @@ func TestStatusCollector_Collect(t *testing.T) {
 		cometClient := mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
 			return CometStatus{}, errors.New("status error")
 		})
-		coll := NewStatusCollector(cometClient, timeout)
+		coll := NewStatusCollector(cometClient, timeout, 10)
 		var pod corev1.Pod
 		pod.Status.PodIP = "1.1.1.1"
 		got := coll.Collect(ctx, []corev1.Pod{pod})
@@ -107,10 +117,121 @@ func TestStatusCollector_Collect(t *testing.T) {
 		require.NotZero(t, got[0].Timestamp())
 	})
 
+	t.Run("wrong network", func(t *testing.T) {
+		cometClient := mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+			var status CometStatus
+			status.Result.NodeInfo.Network = "osmo-test-5"
+			return status, nil
+		})
+		coll := NewStatusCollector(cometClient, timeout, 10)
+
+		var pod corev1.Pod
+		pod.Status.PodIP = "1.1.1.1"
+		pod.Annotations = map[string]string{kube.ChainIDAnnotation: "osmosis-1"}
+		got := coll.Collect(ctx, []corev1.Pod{pod})
+
+		require.Len(t, got, 1)
+		_, err := got[0].GetStatus()
+		var wrongNetwork *WrongNetworkError
+		require.ErrorAs(t, err, &wrongNetwork)
+		require.Equal(t, WrongNetworkError{Want: "osmosis-1", Got: "osmo-test-5"}, *wrongNetwork)
+		require.EqualError(t, err, `wrong network: node reports chain ID "osmo-test-5", expected "osmosis-1"`)
+		require.Empty(t, got.SyncedPods())
+
+		pod.Annotations[kube.ChainIDAnnotation] = "osmo-test-5"
+		got = coll.Collect(ctx, []corev1.Pod{pod})
+		_, err = got[0].GetStatus()
+		require.NoError(t, err)
+	})
+
 	t.Run("no pods", func(t *testing.T) {
-		coll := NewStatusCollector(panicStatuser, timeout)
+		coll := NewStatusCollector(panicStatuser, timeout, 10)
 		got := coll.Collect(ctx, nil)
 
 		require.Empty(t, got)
 	})
+
+	t.Run("max in flight", func(t *testing.T) {
+		var inFlight, maxSeen atomic.Int64
+		cometClient := mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := maxSeen.Load()
+				if n <= seen || maxSeen.CompareAndSwap(seen, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return CometStatus{}, nil
+		})
+		pods := lo.Map(lo.Range(20), func(i int, _ int) corev1.Pod {
+			var pod corev1.Pod
+			pod.Status.PodIP = strconv.Itoa(i)
+			return pod
+		})
+
+		coll := NewStatusCollector(cometClient, timeout, 3)
+		got := coll.Collect(ctx, pods)
+
+		require.Len(t, got, 20)
+		require.EqualValues(t, 3, maxSeen.Load())
+	})
+
+	t.Run("circuit breaker", func(t *testing.T) {
+		var calls int
+		cometClient := mockStatuser(func(ctx context.Context, rpcHost string) (CometStatus, error) {
+			calls++
+			return CometStatus{}, errors.New("connection refused")
+		})
+		coll := NewStatusCollector(cometClient, timeout, 10)
+		var pod corev1.Pod
+		pod.UID = "dead"
+		pod.Status.PodIP = "1.1.1.1"
+
+		for i := 0; i < breakerThreshold; i++ {
+			got := coll.Collect(ctx, []corev1.Pod{pod})
+			_, err := got[0].GetStatus()
+			require.EqualError(t, err, "connection refused")
+		}
+
+		got := coll.Collect(ctx, []corev1.Pod{pod})
+		_, err := got[0].GetStatus()
+		require.ErrorIs(t, err, errCircuitOpen)
+		require.ErrorContains(t, err, "connection refused")
+		require.Equal(t, breakerThreshold, calls)
+	})
+	t.Run("details", func(t *testing.T) {
+		var calls int
+		cometClient := mockDetailsStatuser{
+			mockStatuser: func(ctx context.Context, rpcHost string) (CometStatus, error) {
+				return CometStatus{}, nil
+			},
+			DetailsFn: func(ctx context.Context, rpcHost string) (NodeDetails, error) {
+				calls++
+				require.Equal(t, "http://1.1.1.1:26657", rpcHost)
+				if calls > 1 {
+					return NodeDetails{}, errors.New("boom")
+				}
+				return NodeDetails{AppVersion: "v1.0.0", Peers: 3}, nil
+			},
+		}
+		coll := NewStatusCollector(cometClient, timeout, 10)
+		var pod corev1.Pod
+		pod.UID = "test"
+		pod.Status.PodIP = "1.1.1.1"
+
+		got := coll.Collect(ctx, []corev1.Pod{pod})
+		require.Equal(t, &NodeDetails{AppVersion: "v1.0.0", Peers: 3}, got[0].Details)
+
+		// Cached.
+		got = coll.Collect(ctx, []corev1.Pod{pod})
+		require.Equal(t, "v1.0.0", got[0].Details.AppVersion)
+		require.Equal(t, 1, calls)
+
+		// Stale details are kept if refreshing fails.
+		details := coll.details.Get(ctx, cometClient, pod.UID, "http://1.1.1.1:26657", time.Now().Add(detailsInterval))
+		require.Equal(t, "v1.0.0", details.AppVersion)
+		require.Equal(t, 2, calls)
+	})
 }
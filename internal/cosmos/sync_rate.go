@@ -0,0 +1,131 @@
+package cosmos
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// Samples older than this are discarded, so rates reflect recent progress.
+	syncRateWindow = 3 * time.Minute
+	// Rates are not computed until samples span at least this long, to avoid noisy estimates.
+	syncRateMinSpan = 20 * time.Second
+	// Caps memory per pod regardless of poll or block frequency.
+	maxHeightSamples = 256
+)
+
+// SyncRate describes how fast a pod's height is increasing.
+type SyncRate struct {
+	// Blocks per second the pod processed over Window.
+	BlocksPerSecond float64
+	// Blocks per second the chain produced, derived from the block times of the same blocks.
+	// Zero if block times are unavailable.
+	ChainBlocksPerSecond float64
+	// Duration covered by the samples.
+	Window time.Duration
+}
+
+type heightSample struct {
+	ts        time.Time
+	height    uint64
+	blockTime time.Time
+}
+
+type podHeightHistory struct {
+	samples []heightSample
+	// When the pod's height last increased or, if it never increased, was first observed.
+	changedAt time.Time
+}
+
+// heightHistory keeps a short history of heights per pod.
+type heightHistory map[types.UID]*podHeightHistory
+
+// Observe records the height of each successfully collected item and forgets pods not in coll.
+func (h heightHistory) Observe(coll StatusCollection) {
+	seen := make(map[types.UID]bool, len(coll))
+	for _, item := range coll {
+		uid := item.GetPod().UID
+		seen[uid] = true
+		if item.Err != nil {
+			continue
+		}
+		sample := heightSample{
+			ts:        item.Timestamp(),
+			height:    item.Status.LatestBlockHeight(),
+			blockTime: item.Status.Result.SyncInfo.LatestBlockTime,
+		}
+		if sample.height == 0 {
+			continue
+		}
+		ph := h[uid]
+		if ph == nil {
+			ph = &podHeightHistory{changedAt: sample.ts}
+			h[uid] = ph
+		}
+		samples := ph.samples
+		if n := len(samples); n > 0 {
+			last := samples[n-1]
+			if !sample.ts.After(last.ts) {
+				continue
+			}
+			if sample.height > last.height {
+				ph.changedAt = sample.ts
+			}
+		}
+		samples = append(samples, sample)
+		cutoff := sample.ts.Add(-syncRateWindow)
+		for len(samples) > 1 && (samples[0].ts.Before(cutoff) || len(samples) > maxHeightSamples) {
+			samples = samples[1:]
+		}
+		ph.samples = samples
+	}
+	for uid := range h {
+		if !seen[uid] {
+			delete(h, uid)
+		}
+	}
+}
+
+// Rate returns the pod's sync rate, or false if there is not enough history.
+func (h heightHistory) Rate(uid types.UID) (SyncRate, bool) {
+	ph := h[uid]
+	if ph == nil || len(ph.samples) < 2 {
+		return SyncRate{}, false
+	}
+	samples := ph.samples
+	first, last := samples[0], samples[len(samples)-1]
+	span := last.ts.Sub(first.ts)
+	if span < syncRateMinSpan || last.height < first.height {
+		return SyncRate{}, false
+	}
+	blocks := float64(last.height - first.height)
+	rate := SyncRate{
+		BlocksPerSecond: blocks / span.Seconds(),
+		Window:          span,
+	}
+	if chainSpan := last.blockTime.Sub(first.blockTime); blocks > 0 && chainSpan > 0 && !first.blockTime.IsZero() {
+		rate.ChainBlocksPerSecond = blocks / chainSpan.Seconds()
+	}
+	return rate, true
+}
+
+// HeightChangedAt returns when the pod's height last increased or, if it has not increased, when it was first
+// observed. Returns false if the pod has no history.
+func (h heightHistory) HeightChangedAt(uid types.UID) (time.Time, bool) {
+	ph := h[uid]
+	if ph == nil {
+		return time.Time{}, false
+	}
+	return ph.changedAt, true
+}
+
+// LastBlock returns the last height and block time observed for the pod. Returns false if the pod has no history.
+func (h heightHistory) LastBlock(uid types.UID) (Block, bool) {
+	ph := h[uid]
+	if ph == nil || len(ph.samples) == 0 {
+		return Block{}, false
+	}
+	last := ph.samples[len(ph.samples)-1]
+	return Block{Height: last.height, Time: last.blockTime}, true
+}
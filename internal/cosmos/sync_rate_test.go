@@ -0,0 +1,99 @@
+package cosmos
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestHeightHistory(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	item := func(uid string, height uint64, ts, blockTime time.Time) StatusItem {
+		var pod corev1.Pod
+		pod.UID = types.UID("uid-" + uid)
+		var status CometStatus
+		status.Result.SyncInfo.LatestBlockHeight = strconv.FormatUint(height, 10)
+		status.Result.SyncInfo.LatestBlockTime = blockTime
+		return StatusItem{Pod: &pod, Status: status, TS: ts}
+	}
+
+	t.Run("rate", func(t *testing.T) {
+		history := make(heightHistory)
+		history.Observe(StatusCollection{item("0", 100, now, now.Add(-time.Hour))})
+
+		_, ok := history.Rate("uid-0")
+		require.False(t, ok)
+
+		// Span too short.
+		history.Observe(StatusCollection{item("0", 110, now.Add(10*time.Second), now.Add(-time.Hour))})
+		_, ok = history.Rate("uid-0")
+		require.False(t, ok)
+
+		// 1000 blocks in 50s, spanning 100s of chain time.
+		history.Observe(StatusCollection{item("0", 1100, now.Add(50*time.Second), now.Add(-time.Hour+100*time.Second))})
+		rate, ok := history.Rate("uid-0")
+		require.True(t, ok)
+		require.InDelta(t, 20, rate.BlocksPerSecond, 0.001)
+		require.InDelta(t, 10, rate.ChainBlocksPerSecond, 0.001)
+		require.Equal(t, 50*time.Second, rate.Window)
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		history := make(heightHistory)
+		history.Observe(StatusCollection{item("0", 100, now, now)})
+		history.Observe(StatusCollection{item("0", 100, now.Add(time.Minute), now)})
+
+		rate, ok := history.Rate("uid-0")
+		require.True(t, ok)
+		require.Zero(t, rate.BlocksPerSecond)
+		require.Zero(t, rate.ChainBlocksPerSecond)
+
+		changedAt, ok := history.HeightChangedAt("uid-0")
+		require.True(t, ok)
+		require.Equal(t, now, changedAt)
+
+		history.Observe(StatusCollection{item("0", 101, now.Add(2*time.Minute), now)})
+		changedAt, _ = history.HeightChangedAt("uid-0")
+		require.Equal(t, now.Add(2*time.Minute), changedAt)
+
+		_, ok = history.HeightChangedAt("uid-1")
+		require.False(t, ok)
+
+		block, ok := history.LastBlock("uid-0")
+		require.True(t, ok)
+		require.Equal(t, Block{Height: 101, Time: now}, block)
+
+		_, ok = history.LastBlock("uid-1")
+		require.False(t, ok)
+	})
+
+	t.Run("window", func(t *testing.T) {
+		history := make(heightHistory)
+		history.Observe(StatusCollection{item("0", 100, now, now)})
+		history.Observe(StatusCollection{item("0", 200, now.Add(time.Minute), now)})
+		history.Observe(StatusCollection{item("0", 200, now.Add(time.Minute+syncRateWindow), now)})
+
+		rate, ok := history.Rate("uid-0")
+		require.True(t, ok)
+		require.Zero(t, rate.BlocksPerSecond)
+		require.Equal(t, syncRateWindow, rate.Window)
+	})
+
+	t.Run("ignores errors and forgets missing pods", func(t *testing.T) {
+		history := make(heightHistory)
+		history.Observe(StatusCollection{item("0", 100, now, now), item("1", 100, now, now)})
+
+		errItem := item("0", 200, now.Add(time.Minute), now)
+		errItem.Err = errCircuitOpen
+		history.Observe(StatusCollection{errItem})
+
+		require.Len(t, history, 1)
+		require.Len(t, history["uid-0"].samples, 1)
+	})
+}
@@ -9,8 +9,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const revisionLabel = "app.kubernetes.io/revision"
-
 // Resource is a diffable kubernetes object.
 type Resource[T client.Object] interface {
 	Object() T
@@ -120,7 +118,7 @@ type currentAdapter[T client.Object] struct {
 }
 
 func (a currentAdapter[T]) Object() T        { return a.obj }
-func (a currentAdapter[T]) Revision() string { return a.obj.GetLabels()[revisionLabel] }
+func (a currentAdapter[T]) Revision() string { return a.obj.GetLabels()[kube.RevisionLabel] }
 
 func (a currentAdapter[T]) Ordinal() int64 {
 	val, _ := strconv.ParseInt(a.obj.GetAnnotations()[kube.OrdinalAnnotation], 10, 64)
@@ -161,7 +159,7 @@ func (diff *Diff[T]) toObjects(list []Resource[T]) []T {
 		if labels == nil {
 			labels = make(map[string]string)
 		}
-		labels[revisionLabel] = list[i].Revision()
+		labels[kube.RevisionLabel] = list[i].Revision()
 		obj.SetLabels(labels)
 
 		annotations := obj.GetAnnotations()
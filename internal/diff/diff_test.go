@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/samber/lo"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,7 +33,7 @@ func TestOrdinalDiff_CreatesDeletesUpdates(t *testing.T) {
 
 	t.Run("create", func(t *testing.T) {
 		current := []*corev1.Pod{
-			{ObjectMeta: metav1.ObjectMeta{Name: "pod-0", Namespace: "default", Labels: map[string]string{revisionLabel: "rev"}}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "pod-0", Namespace: "default", Labels: map[string]string{kube.RevisionLabel: "rev"}}},
 		}
 
 		// Purposefully unordered
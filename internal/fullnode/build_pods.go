@@ -1,6 +1,8 @@
 package fullnode
 
 import (
+	"slices"
+
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	"github.com/strangelove-ventures/cosmos-operator/internal/diff"
 	corev1 "k8s.io/api/core/v1"
@@ -80,6 +82,32 @@ func setVersionedImages(pod *corev1.Pod, v *cosmosv1.ChainVersion) {
 	}
 }
 
+// setVersionedNode applies the version's start args, env and resources to the node container.
+func setVersionedNode(pod *corev1.Pod, crd *cosmosv1.CosmosFullNode, v *cosmosv1.ChainVersion) {
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.Name != mainContainer {
+			continue
+		}
+		if len(v.AdditionalStartArgs) > 0 {
+			cmd, args := startCmdAndArgs(crd, v)
+			c.Command = []string{cmd}
+			c.Args = args
+		}
+		for _, env := range v.Env {
+			if j := slices.IndexFunc(c.Env, func(e corev1.EnvVar) bool { return e.Name == env.Name }); j >= 0 {
+				c.Env[j] = env
+			} else {
+				c.Env = append(c.Env, env)
+			}
+		}
+		if v.Resources != nil {
+			c.Resources = *v.Resources.DeepCopy()
+		}
+		return
+	}
+}
+
 func setChainContainerImage(pod *corev1.Pod, image string) {
 	for i := range pod.Spec.Containers {
 		if pod.Spec.Containers[i].Name == mainContainer {
@@ -96,10 +124,73 @@ func setChainContainerImage(pod *corev1.Pod, image string) {
 	}
 }
 
+// ChainContainerImage returns the image of the pod's chain node container, or empty string if not found.
+func ChainContainerImage(pod *corev1.Pod) string {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == mainContainer {
+			return c.Image
+		}
+	}
+	return ""
+}
+
+// ChainVersionAt returns the chain version that applies at height, or nil if height precedes all versions.
+func ChainVersionAt(crd *cosmosv1.CosmosFullNode, height uint64) *cosmosv1.ChainVersion {
+	var vrs *cosmosv1.ChainVersion
+	for i, v := range crd.Spec.ChainSpec.Versions {
+		if height < v.UpgradeHeight {
+			break
+		}
+		vrs = &crd.Spec.ChainSpec.Versions[i]
+	}
+	return vrs
+}
+
+// InstanceChainVersion returns the chain version that applies to the instance at its reported height, or nil if
+// none applies. An upgrade reported from the instance's upgrade-info.json with an image in spec.chain.upgradeImages
+// takes precedence over versions at a lower height.
+func InstanceChainVersion(crd *cosmosv1.CosmosFullNode, instance string) *cosmosv1.ChainVersion {
+	return instanceChainVersionAt(crd, instance, crd.Status.Height[instance])
+}
+
+func instanceChainVersionAt(crd *cosmosv1.CosmosFullNode, instance string, height uint64) *cosmosv1.ChainVersion {
+	vrs := ChainVersionAt(crd, height)
+	upgrade, image, ok := appliedUpgrade(crd, instance, height)
+	if !ok || (vrs != nil && vrs.UpgradeHeight >= upgrade.Height) {
+		return vrs
+	}
+	return &cosmosv1.ChainVersion{UpgradeHeight: upgrade.Height, Image: image}
+}
+
+// appliedUpgrade returns the instance's latest upgrade at height with an image in spec.chain.upgradeImages.
+func appliedUpgrade(crd *cosmosv1.CosmosFullNode, instance string, height uint64) (cosmosv1.UpgradeStatus, string, bool) {
+	for _, upgrades := range []map[string]cosmosv1.UpgradeStatus{crd.Status.Upgrades, crd.Status.AppliedUpgrades} {
+		upgrade, ok := upgrades[instance]
+		if !ok || height < upgrade.Height {
+			continue
+		}
+		if image, ok := crd.Spec.ChainSpec.UpgradeImages[upgrade.Name]; ok {
+			return upgrade, image, true
+		}
+	}
+	return cosmosv1.UpgradeStatus{}, "", false
+}
+
+// HasChainVersions returns true if the chain's image depends on the instance's height.
+func HasChainVersions(crd *cosmosv1.CosmosFullNode) bool {
+	return len(crd.Spec.ChainSpec.Versions) > 0 || len(crd.Spec.ChainSpec.UpgradeImages) > 0
+}
+
 func podCandidates(crd *cosmosv1.CosmosFullNode) map[string]struct{} {
 	candidates := make(map[string]struct{})
 	for _, v := range crd.Status.ScheduledSnapshotStatus {
 		candidates[v.PodCandidate] = struct{}{}
 	}
+	for _, v := range crd.Status.SiblingClones {
+		candidates[v.SourcePod] = struct{}{}
+	}
+	for name := range crd.Status.Resets {
+		candidates[name] = struct{}{}
+	}
 	return candidates
 }
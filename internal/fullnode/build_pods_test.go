@@ -250,3 +250,76 @@ func TestBuildPods(t *testing.T) {
 		require.Equal(t, want, got)
 	})
 }
+
+func TestInstanceChainVersion(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	require.False(t, HasChainVersions(&crd))
+	require.Nil(t, InstanceChainVersion(&crd, "osmosis-0"))
+
+	crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+		{Image: "osmosis:v1"},
+		{UpgradeHeight: 200, Image: "osmosis:v3"},
+	}
+	crd.Spec.ChainSpec.UpgradeImages = map[string]string{"v2": "osmosis:v2"}
+	require.True(t, HasChainVersions(&crd))
+
+	crd.Status.Height = map[string]uint64{"osmosis-0": 100, "osmosis-1": 99, "osmosis-2": 200}
+	require.Equal(t, "osmosis:v1", InstanceChainVersion(&crd, "osmosis-0").Image)
+
+	upgrade := cosmosv1.UpgradeStatus{Name: "v2", Height: 100, Pending: true}
+	crd.Status.Upgrades = map[string]cosmosv1.UpgradeStatus{"osmosis-0": upgrade, "osmosis-1": upgrade, "osmosis-2": upgrade}
+
+	require.Equal(t, &cosmosv1.ChainVersion{UpgradeHeight: 100, Image: "osmosis:v2"}, InstanceChainVersion(&crd, "osmosis-0"))
+	require.Equal(t, "osmosis:v1", InstanceChainVersion(&crd, "osmosis-1").Image)
+	// Versions at a higher height take precedence.
+	require.Equal(t, "osmosis:v3", InstanceChainVersion(&crd, "osmosis-2").Image)
+
+	crd.Status.Upgrades["osmosis-0"] = cosmosv1.UpgradeStatus{Name: "unknown", Height: 100}
+	require.Equal(t, "osmosis:v1", InstanceChainVersion(&crd, "osmosis-0").Image)
+
+	crd.Spec.ChainSpec.Versions = nil
+	require.Nil(t, InstanceChainVersion(&crd, "osmosis-0"))
+	require.Equal(t, "osmosis:v2", InstanceChainVersion(&crd, "osmosis-2").Image)
+}
+
+func TestInstanceChainVersion_SuccessiveUpgrades(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+		{Image: "osmosis:v1"},
+		{UpgradeHeight: 500, Image: "osmosis:v5"},
+	}
+	crd.Spec.ChainSpec.UpgradeImages = map[string]string{"v2": "osmosis:v2", "v4": "osmosis:v4"}
+
+	report := func(height uint64, name string, upgradeHeight uint64) {
+		upgrade := cosmosv1.UpgradeStatus{Name: name, Height: upgradeHeight, Pending: height == upgradeHeight}
+		ApplyVersionReports(&crd.Status, crd.Spec.ChainSpec.UpgradeImages, map[string]VersionReport{
+			"osmosis-0": {Height: height, Upgrade: &upgrade},
+		})
+	}
+
+	report(100, "v2", 100)
+	require.Equal(t, "osmosis:v2", InstanceChainVersion(&crd, "osmosis-0").Image)
+
+	// A later upgrade without an image keeps the applied upgrade's image.
+	report(300, "v3", 300)
+	require.Equal(t, cosmosv1.UpgradeStatus{Name: "v2", Height: 100}, crd.Status.AppliedUpgrades["osmosis-0"])
+	require.Equal(t, &cosmosv1.ChainVersion{UpgradeHeight: 100, Image: "osmosis:v2"}, InstanceChainVersion(&crd, "osmosis-0"))
+
+	// A newer mapped upgrade replaces it.
+	report(400, "v4", 400)
+	require.Equal(t, "osmosis:v4", InstanceChainVersion(&crd, "osmosis-0").Image)
+
+	// Reported before reaching its height, the next upgrade does not apply yet.
+	report(450, "v4.1", 460)
+	crd.Spec.ChainSpec.UpgradeImages["v4.1"] = "osmosis:v4.1"
+	crd.Status.Height["osmosis-0"] = 450
+	require.Equal(t, "osmosis:v4", InstanceChainVersion(&crd, "osmosis-0").Image)
+
+	// So does a higher versions entry.
+	crd.Status.Height["osmosis-0"] = 500
+	require.Equal(t, "osmosis:v5", InstanceChainVersion(&crd, "osmosis-0").Image)
+}
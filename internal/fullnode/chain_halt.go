@@ -0,0 +1,160 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultChainHaltThreshold = 5 * time.Minute
+
+// Statuser calls the CometBFT RPC status endpoint.
+type Statuser interface {
+	Status(ctx context.Context, rpcHost string) (cosmos.CometStatus, error)
+}
+
+// ChainHalt describes whether the chain is halted.
+type ChainHalt struct {
+	Halted bool
+	// The height all pods are stuck at.
+	Height uint64
+	// The latest block time reported by the pods.
+	BlockTime time.Time
+	// The chain version whose upgrade height matches the halt, if any.
+	Upgrade *cosmosv1.ChainVersion
+	// Reference RPCs that confirmed the halt.
+	Confirmed int
+}
+
+// ChainHaltDetection detects network-wide chain halts.
+type ChainHaltDetection struct {
+	collector StatusCollector
+	reference Statuser
+	timeout   time.Duration
+	now       func() time.Time
+}
+
+// NewChainHaltDetection returns a valid ChainHaltDetection. The reference Statuser queries spec.selfHeal.referenceRPCs.
+func NewChainHaltDetection(collector StatusCollector, reference Statuser) ChainHaltDetection {
+	return ChainHaltDetection{
+		collector: collector,
+		reference: reference,
+		timeout:   5 * time.Second,
+		now:       time.Now,
+	}
+}
+
+// Detect returns a halt if all pods report the same height and the latest block time is older than the threshold.
+// Pods whose status cannot be fetched use the last block observed by the cache.
+// If reference RPCs are configured, a halt is not declared if any reference reports a greater height.
+// Unreachable references are ignored.
+// Assumes crd.Spec.SelfHeal is set or else this method may panic.
+func (d ChainHaltDetection) Detect(ctx context.Context, crd *cosmosv1.CosmosFullNode) ChainHalt {
+	var (
+		halt      ChainHalt
+		threshold = defaultChainHaltThreshold
+		spec      = crd.Spec.SelfHeal
+	)
+	if spec.ChainHaltDetection != nil {
+		threshold = durationOrDefault(spec.ChainHaltDetection.Threshold, defaultChainHaltThreshold)
+	}
+
+	var (
+		found     bool
+		refHeight uint64
+	)
+	for _, item := range d.collector.Collect(ctx, client.ObjectKeyFromObject(crd)) {
+		if item.Reference != nil {
+			refHeight = max(refHeight, item.Reference.Height)
+		}
+		var block cosmos.Block
+		if comet, err := item.GetStatus(); err == nil {
+			block.Height = comet.LatestBlockHeight()
+			block.Time = comet.Result.SyncInfo.LatestBlockTime
+		} else if item.LastBlock != nil {
+			// Nodes often exit at an upgrade height, so fall back to the last block seen.
+			block = *item.LastBlock
+		} else {
+			continue
+		}
+		if found && block.Height != halt.Height {
+			return ChainHalt{}
+		}
+		found = true
+		halt.Height = block.Height
+		if block.Time.After(halt.BlockTime) {
+			halt.BlockTime = block.Time
+		}
+	}
+	if !found || halt.Height == 0 || halt.BlockTime.IsZero() || d.now().Sub(halt.BlockTime) < threshold {
+		return ChainHalt{}
+	}
+	// The cached reference height includes references to other CosmosFullNodes.
+	if refHeight > halt.Height {
+		return ChainHalt{}
+	}
+
+	for _, ref := range spec.ReferenceRPCs {
+		if ref.URL == "" {
+			continue
+		}
+		cctx, cancel := context.WithTimeout(ctx, d.timeout)
+		comet, err := d.reference.Status(cctx, ref.URL)
+		cancel()
+		if err != nil {
+			continue
+		}
+		if comet.LatestBlockHeight() > halt.Height {
+			return ChainHalt{}
+		}
+		halt.Confirmed++
+	}
+
+	halt.Halted = true
+	// An upgrade halts the chain before committing the upgrade height.
+	for i, v := range crd.Spec.ChainSpec.Versions {
+		if v.UpgradeHeight > 0 && (v.UpgradeHeight == halt.Height || v.UpgradeHeight == halt.Height+1) {
+			halt.Upgrade = &crd.Spec.ChainSpec.Versions[i]
+			return halt
+		}
+	}
+	// Or at an upgrade reported from upgrade-info.json.
+	for _, upgrade := range crd.Status.Upgrades {
+		image, ok := crd.Spec.ChainSpec.UpgradeImages[upgrade.Name]
+		if ok && upgrade.Pending && (upgrade.Height == halt.Height || upgrade.Height == halt.Height+1) {
+			halt.Upgrade = &cosmosv1.ChainVersion{UpgradeHeight: upgrade.Height, Image: image}
+			break
+		}
+	}
+	return halt
+}
+
+// Condition returns the ChainHalted condition for the halt.
+func (halt ChainHalt) Condition(generation int64) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               cosmosv1.ConditionChainHalted,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "NotHalted",
+		Message:            "No network-wide halt detected",
+	}
+	if !halt.Halted {
+		return cond
+	}
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = "Halted"
+	cond.Message = fmt.Sprintf("All pods halted at height %d; latest block time %s", halt.Height, halt.BlockTime.Format(time.RFC3339))
+	if halt.Confirmed > 0 {
+		cond.Message += fmt.Sprintf("; confirmed by %d reference RPC(s)", halt.Confirmed)
+	}
+	if halt.Upgrade != nil {
+		cond.Reason = "UpgradeHeight"
+		cond.Message += fmt.Sprintf("; matches upgrade height %d for image %s", halt.Upgrade.UpgradeHeight, halt.Upgrade.Image)
+	}
+	return cond
+}
@@ -0,0 +1,184 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockStatuser func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error)
+
+func (fn mockStatuser) Status(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+	return fn(ctx, rpcHost)
+}
+
+func TestChainHaltDetection_Detect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	blockTime := now.Add(-10 * time.Minute)
+
+	statusItem := func(height string, blockTime time.Time) cosmos.StatusItem {
+		var item cosmos.StatusItem
+		item.Pod = new(corev1.Pod)
+		item.Status.Result.SyncInfo.LatestBlockHeight = height
+		item.Status.Result.SyncInfo.LatestBlockTime = blockTime
+		return item
+	}
+
+	newDetector := func(coll cosmos.StatusCollection, reference mockStatuser) ChainHaltDetection {
+		detector := NewChainHaltDetection(mockStatusCollector{CollectFn: func(_ context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, controller)
+			return coll
+		}}, reference)
+		detector.now = func() time.Time { return now }
+		return detector
+	}
+
+	newCRD := func() cosmosv1.CosmosFullNode {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{}
+		return crd
+	}
+
+	var panicStatuser = mockStatuser(func(context.Context, string) (cosmos.CometStatus, error) {
+		panic("should not be called")
+	})
+
+	t.Run("halted", func(t *testing.T) {
+		errItem := cosmos.StatusItem{Pod: new(corev1.Pod), Err: errors.New("connection refused")}
+		errItem.LastBlock = &cosmos.Block{Height: 100, Time: blockTime}
+		coll := cosmos.StatusCollection{
+			statusItem("100", blockTime),
+			statusItem("100", blockTime.Add(-time.Second)),
+			errItem,
+			{Pod: new(corev1.Pod), Err: errors.New("no history")},
+		}
+		crd := newCRD()
+
+		got := newDetector(coll, panicStatuser).Detect(context.Background(), &crd)
+		require.Equal(t, ChainHalt{Halted: true, Height: 100, BlockTime: blockTime}, got)
+
+		cond := got.Condition(3)
+		require.Equal(t, cosmosv1.ConditionChainHalted, cond.Type)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, "Halted", cond.Reason)
+		require.EqualValues(t, 3, cond.ObservedGeneration)
+		require.Contains(t, cond.Message, "height 100")
+	})
+
+	t.Run("upgrade height", func(t *testing.T) {
+		coll := cosmos.StatusCollection{statusItem("99", blockTime)}
+		crd := newCRD()
+		crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+			{Image: "osmosis:v1"},
+			{UpgradeHeight: 100, Image: "osmosis:v2"},
+		}
+
+		got := newDetector(coll, panicStatuser).Detect(context.Background(), &crd)
+		require.True(t, got.Halted)
+		require.Equal(t, "osmosis:v2", got.Upgrade.Image)
+
+		cond := got.Condition(1)
+		require.Equal(t, "UpgradeHeight", cond.Reason)
+		require.Contains(t, cond.Message, "upgrade height 100 for image osmosis:v2")
+	})
+
+	t.Run("named upgrade", func(t *testing.T) {
+		coll := cosmos.StatusCollection{statusItem("99", blockTime)}
+		crd := newCRD()
+		crd.Spec.ChainSpec.UpgradeImages = map[string]string{"v2": "osmosis:v2"}
+		crd.Status.Upgrades = map[string]cosmosv1.UpgradeStatus{
+			"osmosis-0": {Name: "v2", Height: 100, Pending: true},
+		}
+
+		got := newDetector(coll, panicStatuser).Detect(context.Background(), &crd)
+		require.True(t, got.Halted)
+		require.Equal(t, &cosmosv1.ChainVersion{UpgradeHeight: 100, Image: "osmosis:v2"}, got.Upgrade)
+
+		crd.Status.Upgrades["osmosis-0"] = cosmosv1.UpgradeStatus{Name: "v2", Height: 100}
+		got = newDetector(coll, panicStatuser).Detect(context.Background(), &crd)
+		require.Nil(t, got.Upgrade)
+	})
+
+	t.Run("reference rpcs", func(t *testing.T) {
+		coll := cosmos.StatusCollection{statusItem("100", blockTime)}
+		crd := newCRD()
+		crd.Spec.SelfHeal.ReferenceRPCs = []cosmosv1.ReferenceRPC{
+			{URL: "https://rpc1.example.com"},
+			{URL: "https://rpc2.example.com"},
+			{URL: "https://rpc3.example.com"},
+		}
+
+		var refHeight string
+		reference := mockStatuser(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			var status cosmos.CometStatus
+			switch rpcHost {
+			case "https://rpc1.example.com":
+				return status, errors.New("unreachable")
+			case "https://rpc2.example.com":
+				status.Result.SyncInfo.LatestBlockHeight = "100"
+			default:
+				status.Result.SyncInfo.LatestBlockHeight = refHeight
+			}
+			return status, nil
+		})
+
+		refHeight = "100"
+		got := newDetector(coll, reference).Detect(context.Background(), &crd)
+		require.True(t, got.Halted)
+		require.Equal(t, 2, got.Confirmed)
+		require.Contains(t, got.Condition(1).Message, "confirmed by 2 reference RPC(s)")
+
+		refHeight = "101"
+		got = newDetector(coll, reference).Detect(context.Background(), &crd)
+		require.False(t, got.Halted)
+	})
+
+	t.Run("cached reference tip", func(t *testing.T) {
+		item := statusItem("100", blockTime)
+		item.Reference = &cosmos.ReferenceTip{Height: 101}
+		crd := newCRD()
+
+		got := newDetector(cosmos.StatusCollection{item}, panicStatuser).Detect(context.Background(), &crd)
+		require.False(t, got.Halted)
+
+		item.Reference.Height = 100
+		got = newDetector(cosmos.StatusCollection{item}, panicStatuser).Detect(context.Background(), &crd)
+		require.True(t, got.Halted)
+	})
+
+	t.Run("not halted", func(t *testing.T) {
+		for _, tt := range []struct {
+			Name      string
+			Coll      cosmos.StatusCollection
+			Threshold *metav1.Duration
+		}{
+			{"different heights", cosmos.StatusCollection{statusItem("100", blockTime), statusItem("99", blockTime)}, nil},
+			{"recent block", cosmos.StatusCollection{statusItem("100", now.Add(-time.Minute))}, nil},
+			{"custom threshold", cosmos.StatusCollection{statusItem("100", blockTime)}, &metav1.Duration{Duration: time.Hour}},
+			{"no block time", cosmos.StatusCollection{statusItem("100", time.Time{})}, nil},
+			{"no status", cosmos.StatusCollection{{Pod: new(corev1.Pod), Err: errors.New("boom")}}, nil},
+			{"no pods", nil, nil},
+		} {
+			crd := newCRD()
+			crd.Spec.SelfHeal.ChainHaltDetection = &cosmosv1.ChainHaltDetectionSpec{Threshold: tt.Threshold}
+			got := newDetector(tt.Coll, panicStatuser).Detect(context.Background(), &crd)
+			require.False(t, got.Halted, tt.Name)
+
+			cond := got.Condition(1)
+			require.Equal(t, metav1.ConditionFalse, cond.Status, tt.Name)
+			require.Equal(t, "NotHalted", cond.Reason, tt.Name)
+		}
+	})
+}
@@ -37,7 +37,8 @@ func BuildConfigMaps(crd *cosmosv1.CosmosFullNode, peers Peers, nodeKeys NodeKey
 	for i := startOrdinal; i < startOrdinal+crd.Spec.Replicas; i++ {
 		data := make(map[string]string)
 		instance := instanceName(crd, i)
-		if err := addConfigToml(buf, data, crd, instance, peers); err != nil {
+		vrs := InstanceChainVersion(crd, instance)
+		if err := addConfigToml(buf, data, crd, instance, peers, vrs); err != nil {
 			return nil, err
 		}
 		buf.Reset()
@@ -63,7 +64,7 @@ func BuildConfigMaps(crd *cosmosv1.CosmosFullNode, peers Peers, nodeKeys NodeKey
 			}
 			appCfg.HaltHeight = ptr(haltHeight)
 		}
-		if err := addAppToml(buf, data, appCfg); err != nil {
+		if err := addAppToml(buf, data, appCfg, vrs); err != nil {
 			return nil, err
 		}
 		buf.Reset()
@@ -118,7 +119,7 @@ func defaultApp() decodedToml {
 	return data
 }
 
-func addConfigToml(buf *bytes.Buffer, cmData map[string]string, crd *cosmosv1.CosmosFullNode, instance string, peers Peers) error {
+func addConfigToml(buf *bytes.Buffer, cmData map[string]string, crd *cosmosv1.CosmosFullNode, instance string, peers Peers, vrs *cosmosv1.ChainVersion) error {
 	var (
 		spec = crd.Spec.ChainSpec
 		base = make(decodedToml)
@@ -227,6 +228,15 @@ func addConfigToml(buf *bytes.Buffer, cmData map[string]string, crd *cosmosv1.Co
 		mergemap.Merge(dst, decoded)
 	}
 
+	if vrs != nil && vrs.ConfigOverrides != nil {
+		var decoded decodedToml
+		_, err := toml.Decode(*vrs.ConfigOverrides, &decoded)
+		if err != nil {
+			return fmt.Errorf("invalid toml in version %d comet overrides: %w", vrs.UpgradeHeight, err)
+		}
+		mergemap.Merge(dst, decoded)
+	}
+
 	if err := toml.NewEncoder(buf).Encode(dst); err != nil {
 		return err
 	}
@@ -238,7 +248,7 @@ func commaDelimited(s ...string) string {
 	return strings.Join(lo.Compact(s), ",")
 }
 
-func addAppToml(buf *bytes.Buffer, cmData map[string]string, app cosmosv1.SDKAppConfig) error {
+func addAppToml(buf *bytes.Buffer, cmData map[string]string, app cosmosv1.SDKAppConfig, vrs *cosmosv1.ChainVersion) error {
 	base := make(decodedToml)
 	base["minimum-gas-prices"] = app.MinGasPrice
 	// Note: The name discrepancy "enable" vs. "enabled" is intentional; a known inconsistency within the app.toml.
@@ -273,6 +283,15 @@ func addAppToml(buf *bytes.Buffer, cmData map[string]string, app cosmosv1.SDKApp
 		mergemap.Merge(dst, decoded)
 	}
 
+	if vrs != nil && vrs.AppOverrides != nil {
+		var decoded decodedToml
+		_, err := toml.Decode(*vrs.AppOverrides, &decoded)
+		if err != nil {
+			return fmt.Errorf("invalid toml in version %d app overrides: %w", vrs.UpgradeHeight, err)
+		}
+		mergemap.Merge(dst, decoded)
+	}
+
 	if err := toml.NewEncoder(buf).Encode(dst); err != nil {
 		return err
 	}
@@ -558,6 +558,48 @@ func TestBuildConfigMaps(t *testing.T) {
 			require.Equal(t, overrideAddr1, config["p2p"].(map[string]any)["external-address"])
 		})
 
+		t.Run("version overrides", func(t *testing.T) {
+			versioned := crd.DeepCopy()
+			versioned.Spec.ChainSpec.App.TomlOverrides = ptr(`new-base = "base"`)
+			versioned.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+				{Image: "osmosis:v1"},
+				{
+					UpgradeHeight:   100,
+					Image:           "osmosis:v2",
+					ConfigOverrides: ptr(`new_field = "v2"`),
+					AppOverrides:    ptr(`new-base = "v2"`),
+				},
+			}
+			versioned.Status.Height = map[string]uint64{"osmosis-0": 100, "osmosis-1": 99}
+
+			nodeKeys, err := getMockNodeKeysForCRD(*versioned, "")
+			require.NoError(t, err)
+
+			cms, err := BuildConfigMaps(versioned, nil, nodeKeys)
+			require.NoError(t, err)
+
+			var config, app map[string]any
+			_, err = toml.Decode(cms[0].Object().Data["config-overlay.toml"], &config)
+			require.NoError(t, err)
+			require.Equal(t, "v2", config["new_field"])
+			_, err = toml.Decode(cms[0].Object().Data["app-overlay.toml"], &app)
+			require.NoError(t, err)
+			require.Equal(t, "v2", app["new-base"])
+
+			config, app = nil, nil
+			_, err = toml.Decode(cms[1].Object().Data["config-overlay.toml"], &config)
+			require.NoError(t, err)
+			require.NotContains(t, config, "new_field")
+			_, err = toml.Decode(cms[1].Object().Data["app-overlay.toml"], &app)
+			require.NoError(t, err)
+			require.Equal(t, "base", app["new-base"])
+
+			versioned.Spec.ChainSpec.Versions[1].AppOverrides = ptr(`invalid_toml = should be invalid`)
+			_, err = BuildConfigMaps(versioned, nil, nodeKeys)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid toml in version 100 app overrides")
+		})
+
 		t.Run("invalid toml", func(t *testing.T) {
 			malformed := crd.DeepCopy()
 			malformed.Spec.ChainSpec.App.TomlOverrides = ptr(`invalid_toml = should be invalid`)
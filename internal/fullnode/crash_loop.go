@@ -0,0 +1,186 @@
+package fullnode
+
+import (
+	"strings"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultCrashLoopCooldown = 15 * time.Minute
+	maxCrashLoopMessageLen   = 256
+)
+
+// CrashLoop is a crash looping node container.
+type CrashLoop struct {
+	Pod    *corev1.Pod
+	Status cosmosv1.InstanceCrashLoopStatus
+}
+
+// Patterns are matched against the lowercased termination message in order. The node container uses
+// the FallbackToLogsOnError termination message policy, so the message is usually the last log lines.
+var crashLoopPatterns = []struct {
+	Class    cosmosv1.CrashLoopClass
+	Contains []string
+}{
+	{cosmosv1.CrashLoopDiskFull, []string{"no space left on device"}},
+	{cosmosv1.CrashLoopWrongBinary, []string{
+		"needed at height",
+		"binary updated before trigger",
+		"wrong block.header.lastresultshash",
+	}},
+	{cosmosv1.CrashLoopAppHashMismatch, []string{
+		"wrong block.header.apphash",
+		"apphash mismatch",
+		"app hash mismatch",
+	}},
+	{cosmosv1.CrashLoopDBCorruption, []string{
+		"lock: resource temporarily unavailable",
+		".sst: no such file or directory",
+		".ldb: no such file or directory",
+		"missing sst",
+		"corrupt",
+	}},
+}
+
+// ClassifyCrashLoop returns the probable cause if the pod's node container is in CrashLoopBackOff.
+// Returns nil if the container is not crash looping.
+func ClassifyCrashLoop(pod *corev1.Pod) *CrashLoop {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != mainContainer {
+			continue
+		}
+		term := cs.LastTerminationState.Terminated
+		if cs.State.Waiting == nil || cs.State.Waiting.Reason != "CrashLoopBackOff" || term == nil {
+			return nil
+		}
+		status := cosmosv1.InstanceCrashLoopStatus{
+			Class:    cosmosv1.CrashLoopUnknown,
+			Reason:   term.Reason,
+			ExitCode: term.ExitCode,
+			Message:  lastLine(term.Message),
+		}
+		if term.Reason == "OOMKilled" {
+			status.Class = cosmosv1.CrashLoopOOMKilled
+			return &CrashLoop{Pod: pod, Status: status}
+		}
+		class, line := classifyMessage(term.Message)
+		if class != "" {
+			status.Class = class
+			status.Message = truncateMessage(line)
+		}
+		return &CrashLoop{Pod: pod, Status: status}
+	}
+	return nil
+}
+
+func classifyMessage(msg string) (cosmosv1.CrashLoopClass, string) {
+	lines := strings.Split(msg, "\n")
+	for _, p := range crashLoopPatterns {
+		// Search from the end; the last lines are closest to the exit.
+		for i := len(lines) - 1; i >= 0; i-- {
+			lower := strings.ToLower(lines[i])
+			for _, s := range p.Contains {
+				if strings.Contains(lower, s) {
+					return p.Class, lines[i]
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+func lastLine(msg string) string {
+	lines := strings.Split(strings.TrimSpace(msg), "\n")
+	return truncateMessage(lines[len(lines)-1])
+}
+
+func truncateMessage(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > maxCrashLoopMessageLen {
+		return s[:maxCrashLoopMessageLen]
+	}
+	return s
+}
+
+// CrashLoops returns the crash looping pods in the collection. Debug pods are excluded.
+func CrashLoops(coll cosmos.StatusCollection) []CrashLoop {
+	var loops []CrashLoop
+	for _, pod := range coll.Pods() {
+		if isDebugPod(pod) {
+			continue
+		}
+		if cl := ClassifyCrashLoop(pod); cl != nil {
+			loops = append(loops, *cl)
+		}
+	}
+	return loops
+}
+
+// CrashLoopAction returns the configured action for the class. Defaults to event only.
+func CrashLoopAction(spec *cosmosv1.CrashLoopRemediationSpec, class cosmosv1.CrashLoopClass) cosmosv1.CrashLoopAction {
+	var action cosmosv1.CrashLoopAction
+	switch class {
+	case cosmosv1.CrashLoopAppHashMismatch:
+		action = spec.AppHashMismatch
+	case cosmosv1.CrashLoopWrongBinary:
+		action = spec.WrongBinary
+	case cosmosv1.CrashLoopDBCorruption:
+		action = spec.DBCorruption
+	case cosmosv1.CrashLoopOOMKilled:
+		action = spec.OOMKilled
+	case cosmosv1.CrashLoopDiskFull:
+		action = spec.DiskFull
+	}
+	if action == "" {
+		return cosmosv1.CrashLoopActionEvent
+	}
+	return action
+}
+
+// CrashLoopDue returns true if no action was taken for the instance and class within the cooldown.
+// Assumes crd.Spec.SelfHeal.CrashLoopRemediation is set or else this method may panic.
+func CrashLoopDue(crd *cosmosv1.CosmosFullNode, cl CrashLoop, now time.Time) bool {
+	prev := crd.Status.SelfHealing.CrashLoopRemediation[cl.Pod.Name]
+	if prev == nil || prev.Class != cl.Status.Class {
+		return true
+	}
+	cooldown := durationOrDefault(crd.Spec.SelfHeal.CrashLoopRemediation.Cooldown, defaultCrashLoopCooldown)
+	return now.Sub(prev.LastAction.Time) >= cooldown
+}
+
+// ApplyCrashLoopRemediation updates status after actions were taken. An instance's status is kept until the cooldown
+// elapses, so a pod observed between restarts is not acted upon again.
+// Assumes crd.Spec.SelfHeal.CrashLoopRemediation is set or else this method may panic.
+func ApplyCrashLoopRemediation(crd *cosmosv1.CosmosFullNode, status *cosmosv1.FullNodeStatus, loops []CrashLoop, acted map[string]cosmosv1.CrashLoopAction, now time.Time) {
+	var (
+		cooldown = durationOrDefault(crd.Spec.SelfHeal.CrashLoopRemediation.Cooldown, defaultCrashLoopCooldown)
+		m        = make(map[string]*cosmosv1.CrashLoopRemediationStatus)
+		looping  = make(map[string]bool)
+	)
+	for _, cl := range loops {
+		looping[cl.Pod.Name] = true
+	}
+	for name, prev := range status.SelfHealing.CrashLoopRemediation {
+		if looping[name] || now.Sub(prev.LastAction.Time) < cooldown {
+			m[name] = prev
+		}
+	}
+	for _, cl := range loops {
+		if action, ok := acted[cl.Pod.Name]; ok {
+			m[cl.Pod.Name] = &cosmosv1.CrashLoopRemediationStatus{
+				Class:      cl.Status.Class,
+				Action:     action,
+				LastAction: metav1.NewTime(now),
+			}
+		}
+	}
+	if len(m) == 0 {
+		m = nil
+	}
+	status.SelfHealing.CrashLoopRemediation = m
+}
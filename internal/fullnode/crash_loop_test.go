@@ -0,0 +1,192 @@
+package fullnode
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func crashLoopPod(name, reason, msg string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "healthcheck"},
+				{
+					Name:  mainContainer,
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						Reason:   reason,
+						ExitCode: 1,
+						Message:  msg,
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestClassifyCrashLoop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("classes", func(t *testing.T) {
+		for _, tt := range []struct {
+			Reason      string
+			Message     string
+			WantClass   cosmosv1.CrashLoopClass
+			WantMessage string
+		}{
+			{"OOMKilled", "", cosmosv1.CrashLoopOOMKilled, ""},
+			{"Error", "starting ABCI\nwrite /home/operator/cosmos/data/application.db/001.log: no space left on device\n", cosmosv1.CrashLoopDiskFull,
+				"write /home/operator/cosmos/data/application.db/001.log: no space left on device"},
+			{"Error", `ERR UPGRADE "v25" NEEDED at height: 12000: {}`, cosmosv1.CrashLoopWrongBinary, `ERR UPGRADE "v25" NEEDED at height: 12000: {}`},
+			{"Error", "panic: BINARY UPDATED BEFORE TRIGGER! UPGRADE \"v25\" - in binary but not executed on chain", cosmosv1.CrashLoopWrongBinary,
+				"panic: BINARY UPDATED BEFORE TRIGGER! UPGRADE \"v25\" - in binary but not executed on chain"},
+			{"Error", "Error: error during handshake: error on replay: wrong Block.Header.AppHash.  Expected 0A1B, got 2C3D", cosmosv1.CrashLoopAppHashMismatch,
+				"Error: error during handshake: error on replay: wrong Block.Header.AppHash.  Expected 0A1B, got 2C3D"},
+			{"Error", "Error: resource temporarily unavailable\nopen /home/operator/cosmos/data/blockstore.db/LOCK: resource temporarily unavailable", cosmosv1.CrashLoopDBCorruption,
+				"open /home/operator/cosmos/data/blockstore.db/LOCK: resource temporarily unavailable"},
+			{"Error", "IO error: While open a file for random read: data/application.db/000123.sst: No such file or directory", cosmosv1.CrashLoopDBCorruption,
+				"IO error: While open a file for random read: data/application.db/000123.sst: No such file or directory"},
+			{"Error", "leveldb: manifest corrupted (field 'comparer'): missing [file=MANIFEST-000002]", cosmosv1.CrashLoopDBCorruption,
+				"leveldb: manifest corrupted (field 'comparer'): missing [file=MANIFEST-000002]"},
+			{"Error", "some error\nError: unknown flag: --foo\n", cosmosv1.CrashLoopUnknown, "Error: unknown flag: --foo"},
+		} {
+			got := ClassifyCrashLoop(crashLoopPod("osmosis-0", tt.Reason, tt.Message))
+
+			require.NotNil(t, got, tt.Message)
+			require.Equal(t, "osmosis-0", got.Pod.Name)
+			require.Equal(t, tt.WantClass, got.Status.Class, tt.Message)
+			require.Equal(t, tt.WantMessage, got.Status.Message)
+			require.Equal(t, tt.Reason, got.Status.Reason)
+			require.EqualValues(t, 1, got.Status.ExitCode)
+		}
+	})
+
+	t.Run("long message", func(t *testing.T) {
+		got := ClassifyCrashLoop(crashLoopPod("osmosis-0", "Error", strings.Repeat("x", 1000)+" no space left on device"))
+		require.Len(t, got.Status.Message, maxCrashLoopMessageLen)
+	})
+
+	t.Run("not crash looping", func(t *testing.T) {
+		pod := crashLoopPod("osmosis-0", "Error", "boom")
+		pod.Status.ContainerStatuses[1].State = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		require.Nil(t, ClassifyCrashLoop(pod))
+
+		pod = crashLoopPod("osmosis-0", "Error", "boom")
+		pod.Status.ContainerStatuses[1].LastTerminationState = corev1.ContainerState{}
+		require.Nil(t, ClassifyCrashLoop(pod))
+
+		require.Nil(t, ClassifyCrashLoop(new(corev1.Pod)))
+	})
+}
+
+func TestCrashLoops(t *testing.T) {
+	t.Parallel()
+
+	debug := crashLoopPod("osmosis-2", "OOMKilled", "")
+	debug.Labels = map[string]string{debugLabel: "true"}
+	coll := cosmos.StatusCollection{
+		{Pod: crashLoopPod("osmosis-0", "OOMKilled", "")},
+		{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-1"}}},
+		{Pod: debug},
+	}
+
+	got := CrashLoops(coll)
+	require.Len(t, got, 1)
+	require.Equal(t, "osmosis-0", got[0].Pod.Name)
+}
+
+func TestCrashLoopAction(t *testing.T) {
+	t.Parallel()
+
+	spec := cosmosv1.CrashLoopRemediationSpec{
+		AppHashMismatch: cosmosv1.CrashLoopActionReset,
+		OOMKilled:       cosmosv1.CrashLoopActionIncreaseMemory,
+		DiskFull:        cosmosv1.CrashLoopActionExpandPVC,
+	}
+	require.Equal(t, cosmosv1.CrashLoopActionReset, CrashLoopAction(&spec, cosmosv1.CrashLoopAppHashMismatch))
+	require.Equal(t, cosmosv1.CrashLoopActionIncreaseMemory, CrashLoopAction(&spec, cosmosv1.CrashLoopOOMKilled))
+	require.Equal(t, cosmosv1.CrashLoopActionExpandPVC, CrashLoopAction(&spec, cosmosv1.CrashLoopDiskFull))
+	require.Equal(t, cosmosv1.CrashLoopActionEvent, CrashLoopAction(&spec, cosmosv1.CrashLoopDBCorruption))
+	require.Equal(t, cosmosv1.CrashLoopActionEvent, CrashLoopAction(&spec, cosmosv1.CrashLoopUnknown))
+}
+
+func TestCrashLoopDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	crd := defaultCRD()
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{CrashLoopRemediation: &cosmosv1.CrashLoopRemediationSpec{}}
+	cl := CrashLoop{
+		Pod:    &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-0"}},
+		Status: cosmosv1.InstanceCrashLoopStatus{Class: cosmosv1.CrashLoopOOMKilled},
+	}
+
+	require.True(t, CrashLoopDue(&crd, cl, now))
+
+	for _, tt := range []struct {
+		Status cosmosv1.CrashLoopRemediationStatus
+		Want   bool
+	}{
+		{cosmosv1.CrashLoopRemediationStatus{Class: cosmosv1.CrashLoopOOMKilled, LastAction: metav1.NewTime(now.Add(-14 * time.Minute))}, false},
+		{cosmosv1.CrashLoopRemediationStatus{Class: cosmosv1.CrashLoopOOMKilled, LastAction: metav1.NewTime(now.Add(-15 * time.Minute))}, true},
+		{cosmosv1.CrashLoopRemediationStatus{Class: cosmosv1.CrashLoopDiskFull, LastAction: metav1.NewTime(now)}, true},
+	} {
+		status := tt.Status
+		crd.Status.SelfHealing.CrashLoopRemediation = map[string]*cosmosv1.CrashLoopRemediationStatus{"osmosis-0": &status}
+		require.Equal(t, tt.Want, CrashLoopDue(&crd, cl, now), fmt.Sprintf("%+v", tt.Status))
+	}
+
+	crd.Spec.SelfHeal.CrashLoopRemediation.Cooldown = &metav1.Duration{Duration: time.Minute}
+	crd.Status.SelfHealing.CrashLoopRemediation = map[string]*cosmosv1.CrashLoopRemediationStatus{
+		"osmosis-0": {Class: cosmosv1.CrashLoopOOMKilled, LastAction: metav1.NewTime(now.Add(-2 * time.Minute))},
+	}
+	require.True(t, CrashLoopDue(&crd, cl, now))
+}
+
+func TestApplyCrashLoopRemediation(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	crd := defaultCRD()
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{CrashLoopRemediation: &cosmosv1.CrashLoopRemediationSpec{}}
+
+	var status cosmosv1.FullNodeStatus
+	status.SelfHealing.CrashLoopRemediation = map[string]*cosmosv1.CrashLoopRemediationStatus{
+		// Not crash looping but within cooldown.
+		"osmosis-0": {Class: cosmosv1.CrashLoopOOMKilled, Action: cosmosv1.CrashLoopActionEvent, LastAction: metav1.NewTime(now.Add(-time.Minute))},
+		// Still crash looping.
+		"osmosis-1": {Class: cosmosv1.CrashLoopDiskFull, Action: cosmosv1.CrashLoopActionEvent, LastAction: metav1.NewTime(now.Add(-time.Hour))},
+		// Recovered.
+		"osmosis-2": {Class: cosmosv1.CrashLoopDiskFull, Action: cosmosv1.CrashLoopActionEvent, LastAction: metav1.NewTime(now.Add(-time.Hour))},
+	}
+
+	loops := []CrashLoop{
+		{Pod: crashLoopPod("osmosis-1", "Error", ""), Status: cosmosv1.InstanceCrashLoopStatus{Class: cosmosv1.CrashLoopDiskFull}},
+		{Pod: crashLoopPod("osmosis-3", "OOMKilled", ""), Status: cosmosv1.InstanceCrashLoopStatus{Class: cosmosv1.CrashLoopOOMKilled}},
+	}
+	acted := map[string]cosmosv1.CrashLoopAction{"osmosis-3": cosmosv1.CrashLoopActionIncreaseMemory}
+
+	ApplyCrashLoopRemediation(&crd, &status, loops, acted, now)
+
+	got := status.SelfHealing.CrashLoopRemediation
+	require.Len(t, got, 3)
+	require.Contains(t, got, "osmosis-0")
+	require.Contains(t, got, "osmosis-1")
+	require.Equal(t, &cosmosv1.CrashLoopRemediationStatus{
+		Class:      cosmosv1.CrashLoopOOMKilled,
+		Action:     cosmosv1.CrashLoopActionIncreaseMemory,
+		LastAction: metav1.NewTime(now),
+	}, got["osmosis-3"])
+
+	ApplyCrashLoopRemediation(&crd, &status, nil, nil, now.Add(time.Hour))
+	require.Nil(t, status.SelfHealing.CrashLoopRemediation)
+}
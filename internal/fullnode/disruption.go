@@ -0,0 +1,27 @@
+package fullnode
+
+import (
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// disruptionBlocked returns why deleting the pod of instance name is unsafe, or an empty string if it is safe.
+// Deleting is unsafe if no other replica is available and in sync, or if it would exceed the rollout strategy's
+// max unavailable. Available must contain only available, in sync pods.
+func disruptionBlocked(
+	crd *cosmosv1.CosmosFullNode,
+	available []*corev1.Pod,
+	name string,
+	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int,
+) string {
+	others := lo.Reject(available, func(pod *corev1.Pod, _ int) bool { return pod.Name == name })
+	switch {
+	case len(others) == 0:
+		return "no other replica is in sync"
+	case computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), len(available)) < 1:
+		return "too many unavailable replicas"
+	}
+	return ""
+}
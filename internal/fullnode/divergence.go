@@ -0,0 +1,82 @@
+package fullnode
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const quarantineLabel = "cosmos.strange.love/quarantined"
+
+// DivergedPods returns the items of pods that diverged from the majority of pods on the same chain.
+// Debug pods are excluded.
+func DivergedPods(coll cosmos.StatusCollection) []cosmos.StatusItem {
+	return lo.Filter(coll, func(item cosmos.StatusItem, _ int) bool {
+		return item.Divergence != nil && !isDebugPod(item.GetPod())
+	})
+}
+
+// Quarantine removes the pod from the RPC service, which selects on the name label, by mutating its labels.
+// The pod's revision is unchanged, so PodControl does not replace it.
+// Returns false if the pod is already quarantined.
+func Quarantine(pod *corev1.Pod) bool {
+	if pod.Labels[quarantineLabel] != "" {
+		return false
+	}
+	if pod.Labels == nil {
+		pod.Labels = make(map[string]string)
+	}
+	pod.Labels[quarantineLabel] = "true"
+	pod.Labels[kube.NameLabel] += "-quarantined"
+	return true
+}
+
+// divergedConditions returns the instance's conditions with the Diverged condition updated.
+// The condition is only added once a divergence is detected.
+func divergedConditions(prev *cosmosv1.InstanceStatus, div *cosmos.Divergence) []metav1.Condition {
+	var conds []metav1.Condition
+	if prev != nil {
+		conds = slices.Clone(prev.Conditions)
+	}
+	if div == nil {
+		if meta.FindStatusCondition(conds, cosmosv1.ConditionDiverged) != nil {
+			meta.SetStatusCondition(&conds, metav1.Condition{
+				Type:    cosmosv1.ConditionDiverged,
+				Status:  metav1.ConditionFalse,
+				Reason:  "HashesMatch",
+				Message: "No divergence detected",
+			})
+		}
+		return conds
+	}
+	meta.SetStatusCondition(&conds, metav1.Condition{
+		Type:   cosmosv1.ConditionDiverged,
+		Status: metav1.ConditionTrue,
+		Reason: string(div.Kind) + "Mismatch",
+		Message: fmt.Sprintf("At height %d, reported %s %s but %d of %d pods on chain %s reported %s",
+			div.Height, div.Kind, div.Hash, div.Agree, div.Total, div.ChainID, div.Expected),
+	})
+	return conds
+}
+
+// ReportDivergence records a warning event for each instance whose Diverged condition became true since the
+// previous status.
+func ReportDivergence(reporter kube.Reporter, prev, cur map[string]*cosmosv1.InstanceStatus) {
+	for name, stat := range cur {
+		cond := meta.FindStatusCondition(stat.Conditions, cosmosv1.ConditionDiverged)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		if before := prev[name]; before != nil && meta.IsStatusConditionTrue(before.Conditions, cosmosv1.ConditionDiverged) {
+			continue
+		}
+		reporter.RecordError("Diverged", fmt.Errorf("%s: %s", name, cond.Message))
+	}
+}
@@ -0,0 +1,103 @@
+package fullnode
+
+import (
+	"context"
+	"testing"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestDivergedPods(t *testing.T) {
+	t.Parallel()
+
+	div := &cosmos.Divergence{Height: 100}
+	debug := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Labels: map[string]string{debugLabel: "true"}}}
+	coll := cosmos.StatusCollection{
+		{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ok"}}},
+		{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "diverged"}}, Divergence: div},
+		{Pod: debug, Divergence: div},
+	}
+
+	got := DivergedPods(coll)
+	require.Len(t, got, 1)
+	require.Equal(t, "diverged", got[0].GetPod().Name)
+}
+
+func TestQuarantine(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+	require.NoError(t, err)
+
+	require.True(t, Quarantine(pod))
+	require.Equal(t, "true", pod.Labels[quarantineLabel])
+	require.Equal(t, "osmosis-quarantined", pod.Labels[kube.NameLabel])
+
+	require.False(t, Quarantine(pod))
+	require.Equal(t, "osmosis-quarantined", pod.Labels[kube.NameLabel])
+
+	// Quarantined pods are not selected by the RPC service.
+	svc := BuildServices(&crd)
+	rpc := svc[len(svc)-1].Object()
+	require.Equal(t, "osmosis-rpc", rpc.Name)
+	for k, v := range rpc.Spec.Selector {
+		if pod.Labels[k] != v {
+			return
+		}
+	}
+	t.Fatal("rpc service selects quarantined pod")
+}
+
+func TestInstanceStatus_Diverged(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	div := &cosmos.Divergence{
+		ChainID: "osmosis-1", Height: 100, Kind: cosmos.AppHash, Hash: "BAD", Expected: "GOOD", Agree: 2, Total: 3,
+	}
+
+	var pod corev1.Pod
+	pod.Name = "osmosis-0"
+	coll := cosmos.StatusCollection{{Pod: &pod, Divergence: div}}
+	collector := mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+		return coll
+	}}
+
+	got := InstanceStatus(context.Background(), &crd, collector)
+	cond := meta.FindStatusCondition(got["osmosis-0"].Conditions, cosmosv1.ConditionDiverged)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, "AppHashMismatch", cond.Reason)
+	require.Equal(t, "At height 100, reported AppHash BAD but 2 of 3 pods on chain osmosis-1 reported GOOD", cond.Message)
+	require.False(t, cond.LastTransitionTime.IsZero())
+
+	var reporter mockEventReporter
+	ReportDivergence(&reporter, crd.Status.Instances, got)
+	require.Equal(t, []string{"Diverged"}, reporter.reasons)
+
+	// Not reported again.
+	reporter.reasons = nil
+	ReportDivergence(&reporter, got, got)
+	require.Empty(t, reporter.reasons)
+
+	// Cleared once the pod no longer diverges, e.g. after a reset.
+	crd.Status.Instances = got
+	coll[0].Divergence = nil
+	got = InstanceStatus(context.Background(), &crd, collector)
+	cond = meta.FindStatusCondition(got["osmosis-0"].Conditions, cosmosv1.ConditionDiverged)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+
+	// Never added if no divergence.
+	crd.Status.Instances = nil
+	got = InstanceStatus(context.Background(), &crd, collector)
+	require.Empty(t, got["osmosis-0"].Conditions)
+}
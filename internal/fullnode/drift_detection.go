@@ -28,18 +28,24 @@ func NewDriftDetection(collector StatusCollector) DriftDetection {
 	}
 }
 
-// LaggingPods returns pods that are lagging behind the latest block height.
+// LaggingPods returns pods that report themselves as in sync but are lagging behind the latest block height.
+// The latest block height is the max height of the pods or the reference height, if greater.
 func (d DriftDetection) LaggingPods(ctx context.Context, crd *cosmosv1.CosmosFullNode) []*corev1.Pod {
-	synced := d.collector.Collect(ctx, client.ObjectKeyFromObject(crd)).Synced()
+	synced := d.collector.Collect(ctx, client.ObjectKeyFromObject(crd)).ReportedSynced()
 
 	maxHeight := lo.MaxBy(synced, func(a cosmos.StatusItem, b cosmos.StatusItem) bool {
 		return a.Status.LatestBlockHeight() > b.Status.LatestBlockHeight()
 	}).Status.LatestBlockHeight()
+	for _, item := range synced {
+		if item.Reference != nil {
+			maxHeight = max(maxHeight, item.Reference.Height)
+		}
+	}
 
 	thresh := uint64(crd.Spec.SelfHeal.HeightDriftMitigation.Threshold)
 	lagging := lo.FilterMap(synced, func(item cosmos.StatusItem, _ int) (*corev1.Pod, bool) {
 		isLagging := maxHeight-item.Status.LatestBlockHeight() >= thresh
-		return item.GetPod(), isLagging
+		return item.GetPod(), isLagging && !isDebugPod(item.GetPod())
 	})
 
 	avail := d.available(synced.Pods(), 5*time.Second, time.Now())
@@ -80,6 +80,56 @@ func TestDriftDetection_LaggingPods(t *testing.T) {
 		}
 	})
 
+	t.Run("excludes debug pods", func(t *testing.T) {
+		var crd cosmosv1.CosmosFullNode
+		crd.Spec.Replicas = 3
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{Threshold: 10},
+		}
+
+		var coll cosmos.StatusCollection = lo.Map(lo.Range(3), func(_, i int) cosmos.StatusItem {
+			return cosmos.StatusItem{Pod: &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i)}}}
+		})
+		coll[0].Status.Result.SyncInfo.LatestBlockHeight = "100"
+		coll[1].Status.Result.SyncInfo.LatestBlockHeight = "100"
+		coll[2].Status.Result.SyncInfo.LatestBlockHeight = "50"
+		coll[2].Pod.Labels = map[string]string{debugLabel: "true"}
+
+		detector := NewDriftDetection(mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+			return coll
+		}})
+		detector.available = func(pods []*corev1.Pod, _ time.Duration, _ time.Time) []*corev1.Pod { return pods }
+
+		require.Empty(t, detector.LaggingPods(context.Background(), &crd))
+	})
+
+	t.Run("reference height", func(t *testing.T) {
+		var crd cosmosv1.CosmosFullNode
+		crd.Spec.Replicas = 1
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			HeightDriftMitigation: &cosmosv1.HeightDriftMitigationSpec{Threshold: 10},
+		}
+
+		var item cosmos.StatusItem
+		item.Pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-0"}}
+		item.Status.Result.SyncInfo.LatestBlockHeight = "100"
+		item.Reference = &cosmos.ReferenceTip{Height: 120, MaxLag: 5}
+		coll := cosmos.StatusCollection{item}
+
+		detector := NewDriftDetection(mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+			return coll
+		}})
+		detector.available = func(pods []*corev1.Pod, _ time.Duration, _ time.Time) []*corev1.Pod { return pods }
+		detector.computeRollout = func(*intstr.IntOrString, int, int) int { return 1 }
+
+		got := detector.LaggingPods(context.Background(), &crd)
+		require.Len(t, got, 1)
+		require.Equal(t, "pod-0", got[0].Name)
+
+		coll[0].Reference.Height = 105
+		require.Empty(t, detector.LaggingPods(context.Background(), &crd))
+	})
+
 	t.Run("no pods or replicas", func(t *testing.T) {
 		collector := mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
 			return nil
@@ -0,0 +1,172 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	cosmosalpha "github.com/strangelove-ventures/cosmos-operator/api/v1alpha1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FleetInstance summarizes the state of a single CosmosFullNode instance.
+type FleetInstance struct {
+	Namespace string                 `json:"namespace"`
+	FullNode  string                 `json:"fullNode"`
+	Name      string                 `json:"name"`
+	Phase     cosmosv1.FullNodePhase `json:"phase"`
+	PodPhase  corev1.PodPhase        `json:"podPhase,omitempty"`
+	Height    uint64                 `json:"height,omitempty"`
+	// Blocks behind the highest instance of the same CosmosFullNode.
+	Lag          uint64                 `json:"lag"`
+	InSync       *bool                  `json:"inSync,omitempty"`
+	Image        string                 `json:"image,omitempty"`
+	ChainVersion *cosmosv1.ChainVersion `json:"chainVersion,omitempty"`
+	PVCSize      *resource.Quantity     `json:"pvcSize,omitempty"`
+	PVCUsed      *int                   `json:"pvcPercentUsed,omitempty"`
+	Restarts     int32                  `json:"restarts"`
+	// The ScheduledVolumeSnapshot, if any, currently using this instance as its candidate.
+	SnapshotCandidate string `json:"snapshotCandidate,omitempty"`
+}
+
+// FleetCollector summarizes all CosmosFullNodes visible to a client.
+type FleetCollector struct {
+	client     Lister
+	diskClient DiskUsager
+}
+
+// NewFleetCollector returns a valid FleetCollector. If diskClient is nil, disk usage is not collected.
+func NewFleetCollector(client Lister, diskClient DiskUsager) *FleetCollector {
+	return &FleetCollector{client: client, diskClient: diskClient}
+}
+
+// Collect returns every instance of every CosmosFullNode in namespace, sorted by namespace, CosmosFullNode and
+// instance name. An empty namespace collects from all namespaces.
+func (c FleetCollector) Collect(ctx context.Context, namespace string) ([]FleetInstance, error) {
+	var crds cosmosv1.CosmosFullNodeList
+	if err := c.client.List(ctx, &crds, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("list cosmosfullnodes: %w", err)
+	}
+	sort.Slice(crds.Items, func(i, j int) bool {
+		a, b := crds.Items[i], crds.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	var instances []FleetInstance
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		opts := []client.ListOption{
+			client.InNamespace(crd.Namespace),
+			client.MatchingLabels{kube.ControllerLabel: "cosmos-operator", kube.ComponentLabel: cosmosv1.CosmosFullNodeController},
+		}
+		var pods corev1.PodList
+		if err := c.client.List(ctx, &pods, opts...); err != nil {
+			return nil, fmt.Errorf("list pods for %s: %w", client.ObjectKeyFromObject(crd), err)
+		}
+		var pvcs corev1.PersistentVolumeClaimList
+		if err := c.client.List(ctx, &pvcs, opts...); err != nil {
+			return nil, fmt.Errorf("list pvcs for %s: %w", client.ObjectKeyFromObject(crd), err)
+		}
+		found := fleetInstances(crd, ptrSlice(pods.Items), ptrSlice(pvcs.Items))
+		c.addDiskUsage(ctx, crd, found, ptrSlice(pods.Items))
+		instances = append(instances, found...)
+	}
+	return instances, nil
+}
+
+func (c FleetCollector) addDiskUsage(ctx context.Context, crd *cosmosv1.CosmosFullNode, instances []FleetInstance, pods []*corev1.Pod) {
+	if c.diskClient == nil {
+		return
+	}
+	byName := lo.SliceToMap(pods, func(pod *corev1.Pod) (string, *corev1.Pod) { return pod.Name, pod })
+
+	var eg errgroup.Group
+	for i := range instances {
+		pod := byName[instances[i].Name]
+		if pod == nil || pod.Status.PodIP == "" || isDebugPod(pod) {
+			continue
+		}
+		eg.Go(func() error {
+			cctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			resp, err := c.diskClient.DiskUsage(cctx, "http://"+pod.Status.PodIP, ChainHomeDir(crd))
+			if err != nil || resp.AllBytes == 0 {
+				return nil
+			}
+			used := int(math.Round(float64(resp.AllBytes-resp.FreeBytes) / float64(resp.AllBytes) * 100))
+			instances[i].PVCUsed = &used
+			return nil
+		})
+	}
+	_ = eg.Wait()
+}
+
+func fleetInstances(crd *cosmosv1.CosmosFullNode, pods []*corev1.Pod, pvcs []*corev1.PersistentVolumeClaim) []FleetInstance {
+	var (
+		podsByName = lo.SliceToMap(pods, func(pod *corev1.Pod) (string, *corev1.Pod) { return pod.Name, pod })
+		pvcsByName = lo.SliceToMap(pvcs, func(pvc *corev1.PersistentVolumeClaim) (string, *corev1.PersistentVolumeClaim) {
+			return pvc.Name, pvc
+		})
+		candidates = make(map[string]string)
+		svcSuffix  = "." + cosmosalpha.GroupVersion.Version + "." + cosmosalpha.GroupVersion.Group
+	)
+	for key, status := range crd.Status.ScheduledSnapshotStatus {
+		candidates[status.PodCandidate] = strings.TrimPrefix(strings.TrimSuffix(key, svcSuffix), crd.Namespace+".")
+	}
+
+	instances := make([]FleetInstance, 0, crd.Spec.Replicas)
+	var tip uint64
+	for i := crd.Spec.Ordinals.Start; i < crd.Spec.Ordinals.Start+crd.Spec.Replicas; i++ {
+		name := instanceName(crd, i)
+		instance := FleetInstance{
+			Namespace:         crd.Namespace,
+			FullNode:          crd.Name,
+			Name:              name,
+			Phase:             crd.Status.Phase,
+			Height:            crd.Status.Height[name],
+			SnapshotCandidate: candidates[name],
+		}
+		if sync := crd.Status.SyncInfo[name]; sync != nil {
+			if sync.Height != nil {
+				instance.Height = *sync.Height
+			}
+			instance.InSync = sync.InSync
+		}
+		instance.ChainVersion = instanceChainVersionAt(crd, name, instance.Height)
+
+		if pod := podsByName[name]; pod != nil {
+			instance.PodPhase = pod.Status.Phase
+			instance.Image = ChainContainerImage(pod)
+			for _, cs := range pod.Status.ContainerStatuses {
+				instance.Restarts += cs.RestartCount
+			}
+		}
+		if pvc := pvcsByName[pvcName(crd, i)]; pvc != nil {
+			if size, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+				instance.PVCSize = &size
+			}
+		}
+
+		tip = max(tip, instance.Height)
+		instances = append(instances, instance)
+	}
+
+	for i := range instances {
+		if instances[i].Height > 0 {
+			instances[i].Lag = tip - instances[i].Height
+		}
+	}
+	return instances
+}
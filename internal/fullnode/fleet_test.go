@@ -0,0 +1,152 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/healthcheck"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockLister func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+
+func (fn mockLister) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if ctx == nil {
+		panic("nil context")
+	}
+	return fn(ctx, list, opts...)
+}
+
+func TestFleetCollector_Collect(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	crd := defaultCRD()
+	crd.Spec.Replicas = 3
+	crd.Status.Phase = cosmosv1.FullNodePhaseCompete
+	crd.Status.Height = map[string]uint64{"osmosis-0": 90, "osmosis-1": 50}
+	crd.Status.SyncInfo = map[string]*cosmosv1.SyncInfoPodStatus{
+		"osmosis-0": {Height: ptr(uint64(100)), InSync: ptr(true)},
+		"osmosis-1": {InSync: ptr(false)},
+	}
+	crd.Status.ScheduledSnapshotStatus = map[string]cosmosv1.FullNodeSnapshotStatus{
+		"test.daily.v1alpha1.cosmos.strange.love": {PodCandidate: "osmosis-1"},
+	}
+	crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+		{UpgradeHeight: 0, Image: "osmosis:v1"},
+		{UpgradeHeight: 75, Image: "osmosis:v2"},
+	}
+
+	other := defaultCRD()
+	other.Name = "agoric"
+	other.Namespace = "another"
+	other.Spec.Replicas = 1
+
+	pod0 := &corev1.Pod{}
+	pod0.Name = "osmosis-0"
+	pod0.Status.Phase = corev1.PodRunning
+	pod0.Status.PodIP = "10.0.0.1"
+	pod0.Spec.Containers = []corev1.Container{{Name: mainContainer, Image: "osmosis:v2"}}
+	pod0.Status.ContainerStatuses = []corev1.ContainerStatus{{RestartCount: 2}, {RestartCount: 1}}
+
+	pvc0 := &corev1.PersistentVolumeClaim{}
+	pvc0.Name = "pvc-osmosis-0"
+	pvc0.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")}
+
+	lister := mockLister(func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+		var listOpts client.ListOptions
+		for _, opt := range opts {
+			opt.ApplyToList(&listOpts)
+		}
+		switch ref := list.(type) {
+		case *cosmosv1.CosmosFullNodeList:
+			require.Empty(t, listOpts.Namespace)
+			ref.Items = []cosmosv1.CosmosFullNode{crd, other}
+		case *corev1.PodList:
+			require.Equal(t, "app.kubernetes.io/component=CosmosFullNode,app.kubernetes.io/created-by=cosmos-operator", listOpts.LabelSelector.String())
+			if listOpts.Namespace == "test" {
+				ref.Items = []corev1.Pod{*pod0}
+			}
+		case *corev1.PersistentVolumeClaimList:
+			if listOpts.Namespace == "test" {
+				ref.Items = []corev1.PersistentVolumeClaim{*pvc0}
+			}
+		default:
+			panic("unexpected list type")
+		}
+		return nil
+	})
+
+	var diskCalls int
+	diskClient := mockDiskUsager(func(ctx context.Context, host, homeDir string) (healthcheck.DiskUsageResponse, error) {
+		diskCalls++
+		require.Equal(t, "http://10.0.0.1", host)
+		require.Equal(t, "/home/operator/cosmos", homeDir)
+		return healthcheck.DiskUsageResponse{AllBytes: 100, FreeBytes: 25}, nil
+	})
+
+	got, err := NewFleetCollector(lister, diskClient).Collect(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, diskCalls)
+	require.Len(t, got, 4)
+
+	// Sorted by namespace.
+	require.Equal(t, "agoric-0", got[0].Name)
+	require.Equal(t, "another", got[0].Namespace)
+
+	inst := got[1]
+	require.Equal(t, "osmosis-0", inst.Name)
+	require.Equal(t, cosmosv1.FullNodePhaseCompete, inst.Phase)
+	require.Equal(t, corev1.PodRunning, inst.PodPhase)
+	require.EqualValues(t, 100, inst.Height)
+	require.Zero(t, inst.Lag)
+	require.True(t, *inst.InSync)
+	require.Equal(t, "osmosis:v2", inst.Image)
+	require.Equal(t, "osmosis:v2", inst.ChainVersion.Image)
+	require.Equal(t, "100Gi", inst.PVCSize.String())
+	require.Equal(t, 75, *inst.PVCUsed)
+	require.EqualValues(t, 3, inst.Restarts)
+	require.Empty(t, inst.SnapshotCandidate)
+
+	inst = got[2]
+	require.Equal(t, "osmosis-1", inst.Name)
+	require.EqualValues(t, 50, inst.Height)
+	require.EqualValues(t, 50, inst.Lag)
+	require.False(t, *inst.InSync)
+	require.Equal(t, "osmosis:v1", inst.ChainVersion.Image)
+	require.Empty(t, inst.PodPhase)
+	require.Nil(t, inst.PVCSize)
+	require.Nil(t, inst.PVCUsed)
+	require.Equal(t, "daily", inst.SnapshotCandidate)
+
+	inst = got[3]
+	require.Equal(t, "osmosis-2", inst.Name)
+	require.Zero(t, inst.Height)
+	require.Zero(t, inst.Lag)
+	require.Nil(t, inst.InSync)
+
+	t.Run("zero disk capacity", func(t *testing.T) {
+		diskClient := mockDiskUsager(func(context.Context, string, string) (healthcheck.DiskUsageResponse, error) {
+			return healthcheck.DiskUsageResponse{}, nil
+		})
+		got, err := NewFleetCollector(lister, diskClient).Collect(ctx, "")
+		require.NoError(t, err)
+		require.Equal(t, "osmosis-0", got[1].Name)
+		require.Nil(t, got[1].PVCUsed)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		lister := mockLister(func(context.Context, client.ObjectList, ...client.ListOption) error {
+			return errors.New("boom")
+		})
+		_, err := NewFleetCollector(lister, nil).Collect(ctx, "test")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
@@ -11,6 +11,7 @@ import (
 const (
 	networkLabel = "cosmos.strange.love/network"
 	typeLabel    = "cosmos.strange.love/type"
+	debugLabel   = "cosmos.strange.love/debug"
 )
 
 // kv is a list of extra kv pairs to add to the labels. Must be even.
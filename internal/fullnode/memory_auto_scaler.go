@@ -0,0 +1,230 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultMemoryIncrease       = "25%"
+	defaultMemoryMaxFactor      = 2
+	defaultMemoryCooldown       = 30 * time.Minute
+	defaultGoMemLimitPercentage = 90
+)
+
+type MemoryAutoScaler struct {
+	client StatusSyncer
+	now    func() time.Time
+}
+
+func NewMemoryAutoScaler(client StatusSyncer) *MemoryAutoScaler {
+	return &MemoryAutoScaler{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// SignalOOMKilled patches the CosmosFullNode.status.selfHealing with increased memory for each pod whose node
+// container was OOMKilled after the instance's last memory increase, once the cooldown elapsed. Debug pods are skipped.
+// Assumes crd.Spec.SelfHeal.MemoryAutoScale is set or else this method may panic.
+// The CosmosFullNode controller is responsible for recreating pods with the new memory.
+//
+// Returns the names of the instances whose memory increased.
+//
+// Returns an error if patching unsuccessful.
+func (scaler MemoryAutoScaler) SignalOOMKilled(ctx context.Context, crd *cosmosv1.CosmosFullNode, pods []*corev1.Pod) ([]string, error) {
+	var (
+		cooldown  = durationOrDefault(crd.Spec.SelfHeal.MemoryAutoScale.Cooldown, defaultMemoryCooldown)
+		now       = scaler.now()
+		patches   = make(map[string]*cosmosv1.MemoryAutoScaleStatus)
+		joinedErr error
+	)
+	for _, pod := range pods {
+		killedAt, ok := oomKilledAt(pod)
+		if !ok || isDebugPod(pod) {
+			continue
+		}
+		if prev := crd.Status.SelfHealing.MemoryAutoScale[pod.Name]; prev != nil {
+			if !killedAt.After(prev.RequestedAt.Time) || now.Sub(prev.RequestedAt.Time) < cooldown {
+				continue
+			}
+		}
+		next, ok, err := nextMemory(crd, pod.Name)
+		if err != nil {
+			joinedErr = errors.Join(joinedErr, fmt.Errorf("%s: %w", pod.Name, err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		patches[pod.Name] = &cosmosv1.MemoryAutoScaleStatus{
+			RequestedMemory: next,
+			RequestedAt:     metav1.NewTime(now),
+		}
+	}
+
+	if len(patches) == 0 {
+		return nil, joinedErr
+	}
+	names := lo.Keys(patches)
+	slices.Sort(names)
+	return names, errors.Join(joinedErr, scaler.patch(ctx, crd, patches))
+}
+
+// SignalMemoryIncrease patches the CosmosFullNode.status.selfHealing with increased memory for the pod's instance.
+// Unlike SignalOOMKilled, does not check whether the pod was OOMKilled.
+// Assumes crd.Spec.SelfHeal.MemoryAutoScale is set or else this method may panic.
+// The CosmosFullNode controller is responsible for recreating the pod with the new memory.
+//
+// Returns an error with the reason if memory was not increased, e.g. the cooldown or the maximum.
+func (scaler MemoryAutoScaler) SignalMemoryIncrease(ctx context.Context, crd *cosmosv1.CosmosFullNode, pod *corev1.Pod) error {
+	var (
+		cooldown = durationOrDefault(crd.Spec.SelfHeal.MemoryAutoScale.Cooldown, defaultMemoryCooldown)
+		now      = scaler.now()
+	)
+	if prev := crd.Status.SelfHealing.MemoryAutoScale[pod.Name]; prev != nil && now.Sub(prev.RequestedAt.Time) < cooldown {
+		return fmt.Errorf("memory increased to %s within cooldown %s", prev.RequestedMemory.String(), cooldown)
+	}
+	next, ok, err := nextMemory(crd, pod.Name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("memory is at maximum")
+	}
+	patch := &cosmosv1.MemoryAutoScaleStatus{
+		RequestedMemory: next,
+		RequestedAt:     metav1.NewTime(now),
+	}
+	return scaler.patch(ctx, crd, map[string]*cosmosv1.MemoryAutoScaleStatus{pod.Name: patch})
+}
+
+func (scaler MemoryAutoScaler) patch(ctx context.Context, crd *cosmosv1.CosmosFullNode, patches map[string]*cosmosv1.MemoryAutoScaleStatus) error {
+	return scaler.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.MemoryAutoScale == nil {
+			status.SelfHealing.MemoryAutoScale = patches
+			return
+		}
+		for k, v := range patches {
+			status.SelfHealing.MemoryAutoScale[k] = v
+		}
+	})
+}
+
+// nextMemory returns the instance's memory increased by selfHeal.memoryAutoScale.increaseQuantity, up to its maxSize.
+// The pod template's memory limit is used if set, otherwise its memory request. Returns false if the maximum was reached.
+func nextMemory(crd *cosmosv1.CosmosFullNode, name string) (resource.Quantity, bool, error) {
+	base := templateMemory(crd)
+	if base.IsZero() {
+		return resource.Quantity{}, false, errors.New("pod template has no memory request or limit")
+	}
+	var (
+		spec     = crd.Spec.SelfHeal.MemoryAutoScale
+		increase = defaultMemoryIncrease
+		maxMem   = *resource.NewQuantity(base.Value()*defaultMemoryMaxFactor, base.Format)
+	)
+	if spec.IncreaseQuantity != "" {
+		increase = spec.IncreaseQuantity
+	}
+	if !spec.MaxSize.IsZero() {
+		maxMem = spec.MaxSize
+	}
+
+	current := base
+	if status := crd.Status.SelfHealing.MemoryAutoScale[name]; status != nil && status.RequestedMemory.Cmp(base) > 0 {
+		current = status.RequestedMemory
+	}
+	if current.Cmp(maxMem) >= 0 {
+		return resource.Quantity{}, false, nil
+	}
+
+	next, err := calcNextCapacity(current, increase)
+	if err != nil {
+		return resource.Quantity{}, false, fmt.Errorf("calculate memory: %w", err)
+	}
+	if next.Cmp(maxMem) > 0 {
+		next = maxMem
+	}
+	return next, true, nil
+}
+
+// oomKilledAt returns when the pod's node container was last OOMKilled.
+func oomKilledAt(pod *corev1.Pod) (time.Time, bool) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != mainContainer {
+			continue
+		}
+		for _, term := range []*corev1.ContainerStateTerminated{cs.State.Terminated, cs.LastTerminationState.Terminated} {
+			if term != nil && term.Reason == "OOMKilled" {
+				return term.FinishedAt.Time, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// templateMemory returns the pod template's memory limit, or its memory request if no limit is set.
+func templateMemory(crd *cosmosv1.CosmosFullNode) resource.Quantity {
+	res := crd.Spec.PodTemplate.Resources
+	if mem, ok := res.Limits[corev1.ResourceMemory]; ok {
+		return mem
+	}
+	return res.Requests[corev1.ResourceMemory]
+}
+
+// setMemory sets the node container's memory limit if the pod template has one, otherwise its memory request.
+// Memory less than the container's is ignored.
+func setMemory(pod *corev1.Pod, mem resource.Quantity) {
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.Name != mainContainer {
+			continue
+		}
+		if limit, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			if mem.Cmp(limit) <= 0 {
+				return
+			}
+			c.Resources.Limits[corev1.ResourceMemory] = mem
+			return
+		}
+		if req, ok := c.Resources.Requests[corev1.ResourceMemory]; ok && mem.Cmp(req) <= 0 {
+			return
+		}
+		if c.Resources.Requests == nil {
+			c.Resources.Requests = make(corev1.ResourceList)
+		}
+		c.Resources.Requests[corev1.ResourceMemory] = mem
+		return
+	}
+}
+
+// setGoMemLimit sets GOMEMLIMIT for the node container to the percentage of its memory limit.
+// Does nothing if the container has no memory limit or already sets GOMEMLIMIT.
+func setGoMemLimit(pod *corev1.Pod, percentage int32) {
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.Name != mainContainer {
+			continue
+		}
+		limit, ok := c.Resources.Limits[corev1.ResourceMemory]
+		if !ok || lo.ContainsBy(c.Env, func(env corev1.EnvVar) bool { return env.Name == "GOMEMLIMIT" }) {
+			return
+		}
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "GOMEMLIMIT",
+			Value: strconv.FormatInt(limit.Value()*int64(percentage)/100, 10),
+		})
+		return
+	}
+}
@@ -0,0 +1,210 @@
+package fullnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestMemoryAutoScaler_SignalMemoryIncrease(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stubNow := time.Now()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-1"}}
+
+	newCRD := func() cosmosv1.CosmosFullNode {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{MemoryAutoScale: new(cosmosv1.MemoryAutoScaleSpec)}
+		return crd
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		for _, tt := range []struct {
+			Current *resource.Quantity
+			Want    resource.Quantity
+		}{
+			{nil, resource.MustParse("6.25Gi")},
+			{ptr(resource.MustParse("8Gi")), resource.MustParse("10Gi")},
+			// Capped at double the template's memory.
+			{ptr(resource.MustParse("9Gi")), resource.MustParse("10Gi")},
+		} {
+			crd := newCRD()
+			if tt.Current != nil {
+				crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+					"osmosis-1": {RequestedMemory: *tt.Current},
+				}
+			}
+
+			var patchCalled bool
+			syncer := mockStatusSyncer(func(_ context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+				require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, key)
+				var status cosmosv1.FullNodeStatus
+				update(&status)
+				got := status.SelfHealing.MemoryAutoScale["osmosis-1"]
+				require.Equal(t, tt.Want.Value(), got.RequestedMemory.Value(), tt.Want.String())
+				require.Equal(t, stubNow, got.RequestedAt.Time)
+				patchCalled = true
+				return nil
+			})
+
+			scaler := NewMemoryAutoScaler(syncer)
+			scaler.now = func() time.Time { return stubNow }
+			err := scaler.SignalMemoryIncrease(ctx, &crd, pod)
+
+			require.NoError(t, err)
+			require.True(t, patchCalled)
+		}
+	})
+
+	t.Run("spec", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{MemoryAutoScale: &cosmosv1.MemoryAutoScaleSpec{
+			IncreaseQuantity: "2Gi",
+			MaxSize:          resource.MustParse("8Gi"),
+		}}
+		crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+			"osmosis-1": {RequestedMemory: resource.MustParse("7Gi")},
+			// Less than the template, so ignored.
+			"osmosis-2": {RequestedMemory: resource.MustParse("1Gi")},
+		}
+
+		var got cosmosv1.FullNodeStatus
+		syncer := mockStatusSyncer(func(_ context.Context, _ client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			update(&got)
+			return nil
+		})
+		scaler := NewMemoryAutoScaler(syncer)
+
+		err := scaler.SignalMemoryIncrease(ctx, &crd, pod)
+		require.NoError(t, err)
+		require.Equal(t, int64(8<<30), got.SelfHealing.MemoryAutoScale["osmosis-1"].RequestedMemory.Value())
+
+		err = scaler.SignalMemoryIncrease(ctx, &crd, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-2"}})
+		require.NoError(t, err)
+		require.Equal(t, int64(7<<30), got.SelfHealing.MemoryAutoScale["osmosis-2"].RequestedMemory.Value())
+	})
+
+	t.Run("request only", func(t *testing.T) {
+		crd := newCRD()
+		delete(crd.Spec.PodTemplate.Resources.Limits, corev1.ResourceMemory)
+
+		syncer := mockStatusSyncer(func(_ context.Context, _ client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+			var status cosmosv1.FullNodeStatus
+			update(&status)
+			require.Equal(t, int64(625_000_000), status.SelfHealing.MemoryAutoScale["osmosis-1"].RequestedMemory.Value())
+			return nil
+		})
+		err := NewMemoryAutoScaler(syncer).SignalMemoryIncrease(ctx, &crd, pod)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("at max", func(t *testing.T) {
+		crd := newCRD()
+		crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+			"osmosis-1": {RequestedMemory: resource.MustParse("10Gi")},
+		}
+		syncer := mockStatusSyncer(func(context.Context, client.ObjectKey, func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		})
+		err := NewMemoryAutoScaler(syncer).SignalMemoryIncrease(ctx, &crd, pod)
+
+		require.EqualError(t, err, "memory is at maximum")
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		crd := newCRD()
+		crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+			"osmosis-1": {RequestedMemory: resource.MustParse("6Gi"), RequestedAt: metav1.NewTime(stubNow.Add(-time.Minute))},
+		}
+		syncer := mockStatusSyncer(func(context.Context, client.ObjectKey, func(status *cosmosv1.FullNodeStatus)) error {
+			panic("should not be called")
+		})
+		scaler := NewMemoryAutoScaler(syncer)
+		scaler.now = func() time.Time { return stubNow }
+		err := scaler.SignalMemoryIncrease(ctx, &crd, pod)
+
+		require.EqualError(t, err, "memory increased to 6Gi within cooldown 30m0s")
+	})
+
+	t.Run("no memory in template", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.PodTemplate.Resources = corev1.ResourceRequirements{}
+
+		err := NewMemoryAutoScaler(nil).SignalMemoryIncrease(ctx, &crd, pod)
+		require.Error(t, err)
+	})
+}
+
+func TestMemoryAutoScaler_SignalOOMKilled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	stubNow := time.Now()
+
+	oomPod := func(name string, finishedAt time.Time) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: "node",
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason:     "OOMKilled",
+				FinishedAt: metav1.NewTime(finishedAt),
+			}},
+		}}
+		return pod
+	}
+
+	crd := defaultCRD()
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{MemoryAutoScale: &cosmosv1.MemoryAutoScaleSpec{IncreaseQuantity: "1Gi"}}
+	crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+		// Killed again after cooldown.
+		"osmosis-1": {RequestedMemory: resource.MustParse("6Gi"), RequestedAt: metav1.NewTime(stubNow.Add(-time.Hour))},
+		// Killed again within cooldown.
+		"osmosis-2": {RequestedMemory: resource.MustParse("6Gi"), RequestedAt: metav1.NewTime(stubNow.Add(-time.Minute))},
+		// Not killed since the last increase.
+		"osmosis-3": {RequestedMemory: resource.MustParse("6Gi"), RequestedAt: metav1.NewTime(stubNow.Add(-time.Hour))},
+	}
+
+	debug := oomPod("osmosis-5", stubNow)
+	debug.Labels = map[string]string{debugLabel: "true"}
+	pods := []*corev1.Pod{
+		oomPod("osmosis-0", stubNow.Add(-time.Minute)),
+		oomPod("osmosis-1", stubNow.Add(-time.Minute)),
+		oomPod("osmosis-2", stubNow),
+		oomPod("osmosis-3", stubNow.Add(-2*time.Hour)),
+		{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-4"}},
+		debug,
+	}
+
+	var status cosmosv1.FullNodeStatus
+	syncer := mockStatusSyncer(func(_ context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+		require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, key)
+		update(&status)
+		return nil
+	})
+	scaler := NewMemoryAutoScaler(syncer)
+	scaler.now = func() time.Time { return stubNow }
+
+	got, err := scaler.SignalOOMKilled(ctx, &crd, pods)
+	require.NoError(t, err)
+	require.Equal(t, []string{"osmosis-0", "osmosis-1"}, got)
+
+	require.Len(t, status.SelfHealing.MemoryAutoScale, 2)
+	require.Equal(t, int64(6<<30), status.SelfHealing.MemoryAutoScale["osmosis-0"].RequestedMemory.Value())
+	require.Equal(t, int64(7<<30), status.SelfHealing.MemoryAutoScale["osmosis-1"].RequestedMemory.Value())
+	require.Equal(t, stubNow, status.SelfHealing.MemoryAutoScale["osmosis-1"].RequestedAt.Time)
+
+	t.Run("no oom kills", func(t *testing.T) {
+		got, err := NewMemoryAutoScaler(nil).SignalOOMKilled(ctx, &crd, []*corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "osmosis-0"}}})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+}
@@ -0,0 +1,93 @@
+package fullnode
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	instanceLabels = []string{"namespace", "fullnode", "instance"}
+	pvcLabels      = []string{"namespace", "fullnode", "pvc"}
+)
+
+var (
+	syncRateGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Subsystem: "instance",
+		Name:      "sync_blocks_per_second",
+		Help:      "Blocks per second the instance processed over the last few minutes.",
+	}, instanceLabels)
+
+	catchUpETAGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Subsystem: "instance",
+		Name:      "catch_up_eta_seconds",
+		Help:      "Estimated seconds until a catching up instance reaches the highest instance's height.",
+	}, instanceLabels)
+
+	pvcGrowthGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Subsystem: "pvc",
+		Name:      "growth_bytes_per_second",
+		Help:      "Growth of the PVC's used bytes over the last few hours.",
+	}, pvcLabels)
+
+	pvcTimeToFullGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "cosmos_operator",
+		Subsystem: "pvc",
+		Name:      "time_to_full_seconds",
+		Help:      "Projected seconds until the PVC is full at its current growth rate.",
+	}, pvcLabels)
+)
+
+func init() {
+	metrics.Registry.MustRegister(syncRateGauge, catchUpETAGauge, pvcGrowthGauge, pvcTimeToFullGauge)
+}
+
+// RecordInstanceMetrics exports sync metrics for each instance. Metrics for instances without a value are removed.
+func RecordInstanceMetrics(crd *cosmosv1.CosmosFullNode, instances map[string]*cosmosv1.InstanceStatus) {
+	DeleteInstanceMetrics(client.ObjectKeyFromObject(crd))
+	for name, stat := range instances {
+		labels := prometheus.Labels{"namespace": crd.Namespace, "fullnode": crd.Name, "instance": name}
+		if rate, err := strconv.ParseFloat(stat.SyncRate, 64); err == nil {
+			syncRateGauge.With(labels).Set(rate)
+		}
+		if stat.CatchUpETA != nil {
+			catchUpETAGauge.With(labels).Set(stat.CatchUpETA.Seconds())
+		}
+	}
+}
+
+// DeleteInstanceMetrics removes all instance metrics for the CosmosFullNode.
+func DeleteInstanceMetrics(key client.ObjectKey) {
+	labels := prometheus.Labels{"namespace": key.Namespace, "fullnode": key.Name}
+	syncRateGauge.DeletePartialMatch(labels)
+	catchUpETAGauge.DeletePartialMatch(labels)
+}
+
+// RecordPVCMetrics exports growth metrics for each PVC with enough usage history.
+// Metrics for PVCs without growth are removed.
+func RecordPVCMetrics(crd *cosmosv1.CosmosFullNode, usage []PVCDiskUsage) {
+	DeletePVCMetrics(client.ObjectKeyFromObject(crd))
+	for _, pvc := range usage {
+		if pvc.Growth == nil {
+			continue
+		}
+		labels := prometheus.Labels{"namespace": crd.Namespace, "fullnode": crd.Name, "pvc": pvc.Name}
+		pvcGrowthGauge.With(labels).Set(pvc.Growth.BytesPerSecond)
+		if pvc.Growth.TimeToFull > 0 {
+			pvcTimeToFullGauge.With(labels).Set(pvc.Growth.TimeToFull.Seconds())
+		}
+	}
+}
+
+// DeletePVCMetrics removes all PVC metrics for the CosmosFullNode.
+func DeletePVCMetrics(key client.ObjectKey) {
+	labels := prometheus.Labels{"namespace": key.Namespace, "fullnode": key.Name}
+	pvcGrowthGauge.DeletePartialMatch(labels)
+	pvcTimeToFullGauge.DeletePartialMatch(labels)
+}
@@ -9,6 +9,7 @@ import (
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,6 +35,7 @@ type mockClient[T client.Object] struct {
 	PatchCount      int
 	LastPatchObject client.Object
 	LastPatch       client.Patch
+	PatchErr        error
 
 	LastUpdateObject T
 	UpdateCount      int
@@ -61,6 +63,8 @@ func (m *mockClient[T]) Get(ctx context.Context, key client.ObjectKey, obj clien
 		*ref = m.Object.(cosmosv1.CosmosFullNode)
 	case *snapshotv1.VolumeSnapshot:
 		*ref = m.Object.(snapshotv1.VolumeSnapshot)
+	case *storagev1.StorageClass:
+		*ref = m.Object.(storagev1.StorageClass)
 	default:
 		panic(fmt.Errorf("unknown Object type: %T", m.ObjectList))
 	}
@@ -148,7 +152,7 @@ func (m *mockClient[T]) Patch(ctx context.Context, obj client.Object, patch clie
 	m.PatchCount++
 	m.LastPatchObject = obj
 	m.LastPatch = patch
-	return nil
+	return m.PatchErr
 }
 
 func (m *mockClient[T]) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
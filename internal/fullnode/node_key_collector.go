@@ -67,7 +67,6 @@ func NewNodeKeyCollector(client Client) *NodeKeyCollector {
 // Collect node key information given the crd.
 func (c NodeKeyCollector) Collect(ctx context.Context, crd *cosmosv1.CosmosFullNode) (NodeKeys, kube.ReconcileError) {
 	logger := log.FromContext(ctx)
-	nodeKeys := make(NodeKeys)
 
 	var cms corev1.ConfigMapList
 	if err := c.client.List(ctx, &cms,
@@ -77,8 +76,15 @@ func (c NodeKeyCollector) Collect(ctx context.Context, crd *cosmosv1.CosmosFullN
 		return nil, kube.TransientError(fmt.Errorf("list existing configmaps: %w", err))
 	}
 
-	currentCms := ptrSlice(cms.Items)
+	return collectNodeKeys(crd, ptrSlice(cms.Items), func(ordinal int32) (*NodeKey, error) {
+		logger.Info("Generating new node key", "ordinal", ordinal)
+		return randNodeKey()
+	})
+}
 
+// collectNodeKeys reuses node keys found in currentCms and calls newKey for instances without one.
+func collectNodeKeys(crd *cosmosv1.CosmosFullNode, currentCms []*corev1.ConfigMap, newKey func(ordinal int32) (*NodeKey, error)) (NodeKeys, kube.ReconcileError) {
+	nodeKeys := make(NodeKeys)
 	for i := crd.Spec.Ordinals.Start; i < crd.Spec.Ordinals.Start+crd.Spec.Replicas; i++ {
 		var confMap corev1.ConfigMap
 		confMap.Name = instanceName(crd, i)
@@ -99,7 +105,7 @@ func (c NodeKeyCollector) Collect(ctx context.Context, crd *cosmosv1.CosmosFullN
 			// Store the exact value of the node key in the configmap to avoid non-deterministic JSON marshaling which can cause unnecessary updates.
 			marshaledNodeKey = []byte(nodeKeyContent)
 		} else {
-			rNodeKey, err := randNodeKey()
+			rNodeKey, err := newKey(i)
 			if err != nil {
 				return nil, kube.UnrecoverableError(fmt.Errorf("generate node key: %w", err))
 			}
@@ -109,7 +115,6 @@ func (c NodeKeyCollector) Collect(ctx context.Context, crd *cosmosv1.CosmosFullN
 			if err != nil {
 				return nil, kube.UnrecoverableError(fmt.Errorf("marshal node key: %w", err))
 			}
-			logger.Info("Generating new node key", "ordinal", i)
 		}
 
 		nodeKeys[client.ObjectKey{Name: instanceName(crd, i), Namespace: crd.Namespace}] = NodeKeyRepresenter{
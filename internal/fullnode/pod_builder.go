@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
 	"github.com/strangelove-ventures/cosmos-operator/internal/healthcheck"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"github.com/strangelove-ventures/cosmos-operator/internal/version"
@@ -22,9 +26,10 @@ import (
 var bufPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
 
 const (
-	healthCheckPort    = healthcheck.Port
-	mainContainer      = "node"
-	chainInitContainer = "chain-init"
+	healthCheckPort       = healthcheck.Port
+	mainContainer         = "node"
+	chainInitContainer    = "chain-init"
+	versionCheckContainer = "version-check-interval"
 )
 
 // PodBuilder builds corev1.Pods
@@ -43,8 +48,9 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 
 	var (
 		tpl                 = crd.Spec.PodTemplate
-		startCmd, startArgs = startCmdAndArgs(crd)
+		startCmd, startArgs = startCmdAndArgs(crd, nil)
 		probes              = podReadinessProbes(crd)
+		liveness, startup   = podLivenessProbes(crd)
 	)
 
 	versionCheckCmd := []string{"/manager", "versioncheck", "-d"}
@@ -76,19 +82,20 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 			Containers: []corev1.Container{
 				// Main start container.
 				{
-					Name:  mainContainer,
-					Image: tpl.Image,
-					// The following is a useful hack if you need to inspect the PV.
-					//Command: []string{"/bin/sh"},
-					//Args:    []string{"-c", `trap : TERM INT; sleep infinity & wait`},
+					Name:            mainContainer,
+					Image:           tpl.Image,
 					Command:         []string{startCmd},
 					Args:            startArgs,
 					Env:             envVars(crd),
 					Ports:           buildPorts(crd),
 					Resources:       tpl.Resources,
 					ReadinessProbe:  probes[0],
+					LivenessProbe:   liveness,
+					StartupProbe:    startup,
 					ImagePullPolicy: tpl.ImagePullPolicy,
 					WorkingDir:      workDir,
+					// The last log lines help classify crash loops.
+					TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 				},
 				// healthcheck sidecar
 				{
@@ -96,7 +103,7 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 					// Available images: https://github.com/orgs/strangelove-ventures/packages?repo_name=cosmos-operator
 					// IMPORTANT: Must use v0.6.2 or later.
 					Image:   "ghcr.io/strangelove-ventures/cosmos-operator:" + version.DockerTag(),
-					Command: []string{"/manager", "healthcheck", "--rpc-host", fmt.Sprintf("http://localhost:%d", crd.Spec.ChainSpec.Comet.RPCPort())},
+					Command: healthCheckCmd(crd),
 					Ports:   []corev1.ContainerPort{{ContainerPort: healthCheckPort, Protocol: corev1.ProtocolTCP}},
 					Resources: corev1.ResourceRequirements{
 						Requests: corev1.ResourceList{
@@ -111,10 +118,10 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 		},
 	}
 
-	if len(crd.Spec.ChainSpec.Versions) > 0 {
+	if HasChainVersions(crd) {
 		// version check sidecar, runs on inverval in case the instance is halting for upgrade.
 		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
-			Name:    "version-check-interval",
+			Name:    versionCheckContainer,
 			Image:   "ghcr.io/strangelove-ventures/cosmos-operator:" + version.DockerTag(),
 			Command: versionCheckCmd,
 			Resources: corev1.ResourceRequirements{
@@ -130,6 +137,11 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 		})
 	}
 
+	if chainID := crd.Spec.ChainSpec.ChainID; chainID != "" {
+		// Lets the status collector verify the node's network.
+		pod.Annotations[kube.ChainIDAnnotation] = chainID
+	}
+
 	preserveMergeInto(pod.Labels, tpl.Metadata.Labels)
 	preserveMergeInto(pod.Annotations, tpl.Metadata.Annotations)
 
@@ -139,6 +151,50 @@ func NewPodBuilder(crd *cosmosv1.CosmosFullNode) PodBuilder {
 	}
 }
 
+// healthCheckCmd returns the healthcheck sidecar's command. With reference RPCs, the sidecar reports the node
+// as not in sync while it lags the reference height, so its readiness probe removes the pod from services.
+// The InSyncWithAPIs probe strategy also requires the gRPC and REST API servers to respond.
+// The sidecar exports disk usage of the chain home on /metrics and reports the node as not ready if its
+// network is not the configured chain ID.
+func healthCheckCmd(crd *cosmosv1.CosmosFullNode) []string {
+	rpcPort := crd.Spec.ChainSpec.Comet.RPCPort()
+	cmd := []string{"/manager", "healthcheck", "--rpc-host", fmt.Sprintf("http://localhost:%d", rpcPort), "--home", ChainHomeDir(crd)}
+	if chainID := crd.Spec.ChainSpec.ChainID; chainID != "" {
+		cmd = append(cmd, "--chain-id", chainID)
+	}
+	if maxAge := crd.Spec.PodTemplate.Probes.MaxBlockAge; maxAge != nil {
+		cmd = append(cmd, "--max-block-age", maxAge.Duration.String())
+	}
+	if crd.Spec.PodTemplate.Probes.Strategy == cosmosv1.FullNodeProbeStrategyInSyncWithAPIs {
+		cmd = append(cmd,
+			"--grpc-addr", fmt.Sprintf("localhost:%d", grpcPort),
+			"--api-host", fmt.Sprintf("http://localhost:%d", apiPort),
+		)
+	}
+	if crd.Spec.SelfHeal == nil || len(crd.Spec.SelfHeal.ReferenceRPCs) == 0 {
+		return cmd
+	}
+
+	clusterDomain := "cluster.local"
+	if crd.Spec.Service.ClusterDomain != nil {
+		clusterDomain = *crd.Spec.Service.ClusterDomain
+	}
+	for _, ref := range crd.Spec.SelfHeal.ReferenceRPCs {
+		switch {
+		case ref.URL != "":
+			cmd = append(cmd, "--reference-rpc", ref.URL)
+		case ref.CosmosFullNode != nil:
+			ns := ref.CosmosFullNode.Namespace
+			if ns == "" {
+				ns = crd.Namespace
+			}
+			svc := fmt.Sprintf("%s-rpc", kube.ToName(ref.CosmosFullNode.Name))
+			cmd = append(cmd, "--reference-rpc", fmt.Sprintf("http://%s.%s.svc.%s:%d", svc, ns, clusterDomain, rpcPort))
+		}
+	}
+	return append(cmd, "--reference-max-lag", strconv.FormatUint(cosmos.ReferenceMaxLag(crd), 10))
+}
+
 func podReadinessProbes(crd *cosmosv1.CosmosFullNode) []*corev1.Probe {
 	if crd.Spec.PodTemplate.Probes.Strategy == cosmosv1.FullNodeProbeStrategyNone {
 		return []*corev1.Probe{nil, nil}
@@ -166,7 +222,7 @@ func podReadinessProbes(crd *cosmosv1.CosmosFullNode) []*corev1.Probe {
 	sidecarProbe := &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
-				Path:   "/",
+				Path:   healthcheck.ReadinessPath,
 				Port:   intstr.FromInt(healthCheckPort),
 				Scheme: corev1.URISchemeHTTP,
 			},
@@ -181,6 +237,46 @@ func podReadinessProbes(crd *cosmosv1.CosmosFullNode) []*corev1.Probe {
 	return []*corev1.Probe{mainProbe, sidecarProbe}
 }
 
+const (
+	probePeriodSeconds    = 10
+	defaultStartupTimeout = time.Hour
+	defaultFailureTimeout = 2 * time.Minute
+)
+
+// podLivenessProbes returns the node container's liveness and startup probes. They query the healthcheck
+// sidecar, which only requires the node's RPC to be reachable.
+func podLivenessProbes(crd *cosmosv1.CosmosFullNode) (liveness, startup *corev1.Probe) {
+	spec := crd.Spec.PodTemplate.Probes
+	if spec.Strategy == cosmosv1.FullNodeProbeStrategyNone || spec.Liveness == nil {
+		return nil, nil
+	}
+
+	newProbe := func(path string, timeout time.Duration) *corev1.Probe {
+		return &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   path,
+					Port:   intstr.FromInt(healthCheckPort),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			},
+			TimeoutSeconds:   10,
+			PeriodSeconds:    probePeriodSeconds,
+			SuccessThreshold: 1,
+			FailureThreshold: max(1, int32(math.Ceil(timeout.Seconds()/probePeriodSeconds))),
+		}
+	}
+
+	startupTimeout, failureTimeout := defaultStartupTimeout, defaultFailureTimeout
+	if v := spec.Liveness.StartupTimeout; v != nil {
+		startupTimeout = v.Duration
+	}
+	if v := spec.Liveness.FailureTimeout; v != nil {
+		failureTimeout = v.Duration
+	}
+	return newProbe(healthcheck.LivenessPath, failureTimeout), newProbe(healthcheck.StartupPath, startupTimeout)
+}
+
 // Build assigns the CosmosFullNode crd as the owner and returns a fully constructed pod.
 func (b PodBuilder) Build() (*corev1.Pod, error) {
 	pod := b.pod.DeepCopy()
@@ -189,21 +285,16 @@ func (b PodBuilder) Build() (*corev1.Pod, error) {
 		return nil, err
 	}
 
-	if len(b.crd.Spec.ChainSpec.Versions) > 0 {
-		instanceHeight := uint64(0)
-		if height, ok := b.crd.Status.Height[pod.Name]; ok {
-			instanceHeight = height
-		}
-		var vrs *cosmosv1.ChainVersion
-		for _, v := range b.crd.Spec.ChainSpec.Versions {
-			if instanceHeight < v.UpgradeHeight {
-				break
-			}
-			vrs = &v
-		}
-		if vrs != nil {
-			setVersionedImages(pod, vrs)
-		}
+	if vrs := InstanceChainVersion(b.crd, pod.Name); vrs != nil {
+		setVersionedImages(pod, vrs)
+		setVersionedNode(pod, b.crd, vrs)
+	}
+
+	if mem := b.crd.Status.SelfHealing.MemoryAutoScale[pod.Name]; mem != nil {
+		setMemory(pod, mem.RequestedMemory)
+	}
+	if spec := b.crd.Spec.SelfHeal; spec != nil && spec.MemoryAutoScale != nil {
+		setGoMemLimit(pod, lo.FromPtrOr(spec.MemoryAutoScale.GoMemLimitPercentage, defaultGoMemLimitPercentage))
 	}
 
 	if o, ok := b.crd.Spec.InstanceOverrides[pod.Name]; ok {
@@ -216,12 +307,54 @@ func (b PodBuilder) Build() (*corev1.Pod, error) {
 		if o.NodeSelector != nil {
 			pod.Spec.NodeSelector = o.NodeSelector
 		}
+		if o.Debug != nil {
+			setDebugMode(pod, o.Debug)
+		}
 	}
 
 	kube.NormalizeMetadata(&pod.ObjectMeta)
 	return pod, nil
 }
 
+// setDebugMode keeps the chain home mounted but prevents the node from starting, so the PV can be inspected.
+func setDebugMode(pod *corev1.Pod, debug *cosmosv1.InstanceDebugSpec) {
+	pod.Labels[debugLabel] = "true"
+	// Exclude from the RPC service which selects on the name label.
+	pod.Labels[kube.NameLabel] += "-debug"
+
+	if debug.SkipInitContainers {
+		pod.Spec.InitContainers = nil
+	}
+
+	// The version check could restart the pod or update the crd's status while debugging.
+	pod.Spec.Containers = lo.Filter(pod.Spec.Containers, func(c corev1.Container, _ int) bool {
+		return c.Name != versionCheckContainer
+	})
+
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		c.ReadinessProbe = nil
+		if c.Name != mainContainer {
+			continue
+		}
+		if debug.Image != "" {
+			c.Image = debug.Image
+		}
+		if len(debug.Command) > 0 {
+			c.Command = debug.Command
+			c.Args = nil
+			continue
+		}
+		c.Command = []string{"/bin/sh"}
+		c.Args = []string{"-c", `trap : TERM INT; sleep infinity & wait`}
+	}
+}
+
+// isDebugPod returns true if the pod was built in debug mode.
+func isDebugPod(pod *corev1.Pod) bool {
+	return pod.Labels[debugLabel] != ""
+}
+
 const (
 	volChainHome = "vol-chain-home" // Stores live chain data and config files.
 	volTmp       = "vol-tmp"        // Stores temporary config files for manipulation later.
@@ -478,10 +611,11 @@ fi
 	return required
 }
 
-func startCmdAndArgs(crd *cosmosv1.CosmosFullNode) (string, []string) {
+// startCmdAndArgs returns the chain start command. If vrs is not nil, includes its additional start args.
+func startCmdAndArgs(crd *cosmosv1.CosmosFullNode, vrs *cosmosv1.ChainVersion) (string, []string) {
 	var (
 		binary             = crd.Spec.ChainSpec.Binary
-		args               = startCommandArgs(crd)
+		args               = startCommandArgs(crd, vrs)
 		privvalSleep int32 = 10
 	)
 	if v := crd.Spec.ChainSpec.PrivvalSleepSeconds; v != nil {
@@ -497,7 +631,7 @@ func startCmdAndArgs(crd *cosmosv1.CosmosFullNode) (string, []string) {
 	return binary, args
 }
 
-func startCommandArgs(crd *cosmosv1.CosmosFullNode) []string {
+func startCommandArgs(crd *cosmosv1.CosmosFullNode, vrs *cosmosv1.ChainVersion) []string {
 	args := []string{"start", "--home", ChainHomeDir(crd)}
 	cfg := crd.Spec.ChainSpec
 	if cfg.SkipInvariants {
@@ -512,6 +646,9 @@ func startCommandArgs(crd *cosmosv1.CosmosFullNode) []string {
 	if len(crd.Spec.ChainSpec.AdditionalStartArgs) > 0 {
 		args = append(args, crd.Spec.ChainSpec.AdditionalStartArgs...)
 	}
+	if vrs != nil {
+		args = append(args, vrs.AdditionalStartArgs...)
+	}
 	return args
 }
 
@@ -603,21 +740,13 @@ func buildAdditionalPod(
 	pod.Labels[kube.InstanceLabel] = name
 	pod.Labels[kube.BelongsToLabel] = belongsTo
 
-	if len(crd.Spec.ChainSpec.Versions) > 0 {
-		instanceHeight := uint64(0)
-		if height, ok := crd.Status.Height[belongsTo]; ok {
-			instanceHeight = height
-		}
-		var vrs *cosmosv1.ChainVersion
-		for _, v := range crd.Spec.ChainSpec.Versions {
-			if instanceHeight < v.UpgradeHeight {
-				break
-			}
-			vrs = &v
-		}
-		if vrs != nil {
-			setVersionedImages(pod, vrs)
-		}
+	if vrs := InstanceChainVersion(crd, belongsTo); vrs != nil {
+		setVersionedImages(pod, vrs)
+	}
+
+	// Additional pods may use the instance's PVC, so do not run them while the instance is debugging.
+	if crd.Spec.InstanceOverrides[belongsTo].Debug != nil {
+		return nil, nil
 	}
 
 	// Handle instance overrides if needed
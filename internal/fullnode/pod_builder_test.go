@@ -3,6 +3,7 @@ package fullnode
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
@@ -122,6 +123,118 @@ func TestPodBuilder(t *testing.T) {
 		require.Equal(t, "worker-1", pod.Spec.NodeSelector["kubernetes.io/hostname"])
 	})
 
+	t.Run("memory auto scale", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+			"osmosis-1": {RequestedMemory: resource.MustParse("8Gi")},
+		}
+
+		builder := NewPodBuilder(&crd)
+		pod, err := builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		res := pod.Spec.Containers[0].Resources
+		require.Equal(t, resource.MustParse("8Gi"), res.Limits[corev1.ResourceMemory])
+		require.Equal(t, resource.MustParse("500M"), res.Requests[corev1.ResourceMemory])
+		require.Equal(t, corev1.TerminationMessageFallbackToLogsOnError, pod.Spec.Containers[0].TerminationMessagePolicy)
+
+		// Template is unchanged.
+		require.Equal(t, resource.MustParse("5Gi"), crd.Spec.PodTemplate.Resources.Limits[corev1.ResourceMemory])
+
+		pod, err = builder.WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.Equal(t, resource.MustParse("5Gi"), pod.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory])
+
+		// Less than the template.
+		crd.Status.SelfHealing.MemoryAutoScale["osmosis-1"].RequestedMemory = resource.MustParse("1Gi")
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+		require.Equal(t, resource.MustParse("5Gi"), pod.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory])
+		require.NotContains(t, lo.Map(pod.Spec.Containers[0].Env, func(env corev1.EnvVar, _ int) string { return env.Name }), "GOMEMLIMIT")
+	})
+
+	t.Run("memory auto scale - GOMEMLIMIT", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{MemoryAutoScale: &cosmosv1.MemoryAutoScaleSpec{}}
+		crd.Status.SelfHealing.MemoryAutoScale = map[string]*cosmosv1.MemoryAutoScaleStatus{
+			"osmosis-1": {RequestedMemory: resource.MustParse("10Gi")},
+		}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+		env, ok := lo.Find(pod.Spec.Containers[0].Env, func(env corev1.EnvVar) bool { return env.Name == "GOMEMLIMIT" })
+		require.True(t, ok)
+		require.Equal(t, "9663676416", env.Value)
+
+		crd.Spec.SelfHeal.MemoryAutoScale.GoMemLimitPercentage = ptr(int32(50))
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		env, _ = lo.Find(pod.Spec.Containers[0].Env, func(env corev1.EnvVar) bool { return env.Name == "GOMEMLIMIT" })
+		require.Equal(t, "2684354560", env.Value)
+
+		// Set by the pod template.
+		crd.Spec.PodTemplate.Containers = []corev1.Container{{Name: "node", Env: []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "1GiB"}}}}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		got := lo.Filter(pod.Spec.Containers[0].Env, func(env corev1.EnvVar, _ int) bool { return env.Name == "GOMEMLIMIT" })
+		require.Equal(t, []corev1.EnvVar{{Name: "GOMEMLIMIT", Value: "1GiB"}}, got)
+
+		// No memory limit.
+		crd.Spec.PodTemplate.Containers = nil
+		delete(crd.Spec.PodTemplate.Resources.Limits, corev1.ResourceMemory)
+		pod, err = NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.False(t, lo.ContainsBy(pod.Spec.Containers[0].Env, func(env corev1.EnvVar) bool { return env.Name == "GOMEMLIMIT" }))
+	})
+
+	t.Run("instanceOverrides - debug", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{{Image: "osmosis:v1"}}
+		crd.Spec.InstanceOverrides = map[string]cosmosv1.InstanceOverridesSpec{
+			"osmosis-1": {Debug: &cosmosv1.InstanceDebugSpec{}},
+			"osmosis-2": {Debug: &cosmosv1.InstanceDebugSpec{
+				Image:              "busybox:latest",
+				Command:            []string{"/bin/bash"},
+				SkipInitContainers: true,
+			}},
+		}
+
+		builder := NewPodBuilder(&crd)
+		normal, err := builder.WithOrdinal(0).Build()
+		require.NoError(t, err)
+		require.False(t, isDebugPod(normal))
+
+		pod, err := builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.True(t, isDebugPod(pod))
+		require.Equal(t, "osmosis-debug", pod.Labels[kube.NameLabel])
+		require.Equal(t, "osmosis-1", pod.Labels[kube.InstanceLabel])
+		require.Equal(t, "pvc-osmosis-1", PVCName(pod))
+		require.Equal(t, len(normal.Spec.InitContainers), len(pod.Spec.InitContainers))
+
+		containers := lo.Map(pod.Spec.Containers, func(c corev1.Container, _ int) string { return c.Name })
+		require.Equal(t, []string{"node", "healthcheck"}, containers)
+		for _, c := range pod.Spec.Containers {
+			require.Nil(t, c.ReadinessProbe, c.Name)
+		}
+
+		node := pod.Spec.Containers[0]
+		require.Equal(t, "osmosis:v1", node.Image)
+		require.Equal(t, []string{"/bin/sh"}, node.Command)
+		require.Equal(t, []string{"-c", "trap : TERM INT; sleep infinity & wait"}, node.Args)
+		require.Equal(t, normal.Spec.Containers[0].VolumeMounts, node.VolumeMounts)
+
+		pod, err = builder.WithOrdinal(2).Build()
+		require.NoError(t, err)
+
+		require.Empty(t, pod.Spec.InitContainers)
+		node = pod.Spec.Containers[0]
+		require.Equal(t, "busybox:latest", node.Image)
+		require.Equal(t, []string{"/bin/bash"}, node.Command)
+		require.Empty(t, node.Args)
+	})
+
 	t.Run("happy path - ports", func(t *testing.T) {
 		crd := defaultCRD()
 		pod, err := NewPodBuilder(&crd).Build()
@@ -278,6 +391,7 @@ func TestPodBuilder(t *testing.T) {
 		require.NoError(t, err)
 
 		require.Len(t, pod.Spec.Containers, 2)
+		require.Equal(t, "osmosis-123", pod.Annotations["cosmos.strange.love/chain-id"])
 
 		startContainer := pod.Spec.Containers[0]
 		require.Equal(t, "node", startContainer.Name)
@@ -302,7 +416,10 @@ func TestPodBuilder(t *testing.T) {
 		healthContainer := pod.Spec.Containers[1]
 		require.Equal(t, "healthcheck", healthContainer.Name)
 		require.Equal(t, "ghcr.io/strangelove-ventures/cosmos-operator:latest", healthContainer.Image)
-		require.Equal(t, []string{"/manager", "healthcheck", "--rpc-host", "http://localhost:26657"}, healthContainer.Command)
+		require.Equal(t, []string{
+			"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/cosmos",
+			"--chain-id", "osmosis-123",
+		}, healthContainer.Command)
 		require.Empty(t, healthContainer.Args)
 		require.Empty(t, healthContainer.ImagePullPolicy)
 		require.NotEmpty(t, healthContainer.Resources)
@@ -377,6 +494,8 @@ func TestPodBuilder(t *testing.T) {
 		require.Equal(t, container.Env[5].Name, "DATA_DIR")
 		require.Equal(t, container.Env[5].Value, "/home/operator/.osmosisd/data")
 
+		require.Equal(t, []string{"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/.osmosisd"}, pod.Spec.Containers[1].Command)
+
 		require.NotEmpty(t, pod.Spec.InitContainers)
 
 		for _, c := range pod.Spec.InitContainers {
@@ -555,7 +674,7 @@ gaiad start --home /home/operator/cosmos`
 		want = &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/",
+					Path:   "/readyz",
 					Port:   intstr.FromInt(1251),
 					Scheme: "HTTP",
 				},
@@ -571,6 +690,29 @@ gaiad start --home /home/operator/cosmos`
 		require.Equal(t, want, got)
 	})
 
+	t.Run("healthcheck reference rpcs", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			ReferenceMaxLag: ptr(int32(20)),
+			ReferenceRPCs: []cosmosv1.ReferenceRPC{
+				{URL: "https://rpc.example.com:443"},
+				{CosmosFullNode: &cosmosv1.ReferenceFullNode{Name: "osmosis-archive"}},
+				{CosmosFullNode: &cosmosv1.ReferenceFullNode{Name: "osmosis-archive", Namespace: "other"}},
+			},
+		}
+		builder := NewPodBuilder(&crd)
+		pod, err := builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/cosmos",
+			"--reference-rpc", "https://rpc.example.com:443",
+			"--reference-rpc", "http://osmosis-archive-rpc.test.svc.cluster.local:26657",
+			"--reference-rpc", "http://osmosis-archive-rpc.other.svc.cluster.local:26657",
+			"--reference-max-lag", "20",
+		}, pod.Spec.Containers[1].Command)
+	})
+
 	t.Run("probe strategy", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Spec.PodTemplate.Probes = cosmosv1.FullNodeProbesSpec{Strategy: cosmosv1.FullNodeProbeStrategyNone}
@@ -607,6 +749,81 @@ gaiad start --home /home/operator/cosmos`
 
 		require.NotNilf(t, pod.Spec.Containers[0].ReadinessProbe, "container 0")
 		require.NotNilf(t, pod.Spec.Containers[1].ReadinessProbe, "container 1")
+		require.Equal(t, []string{"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/cosmos"}, pod.Spec.Containers[1].Command)
+
+		crd.Spec.PodTemplate.Probes = cosmosv1.FullNodeProbesSpec{Strategy: cosmosv1.FullNodeProbeStrategyInSyncWithAPIs}
+
+		builder = NewPodBuilder(&crd)
+		pod, err = builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.NotNilf(t, pod.Spec.Containers[0].ReadinessProbe, "container 0")
+		require.NotNilf(t, pod.Spec.Containers[1].ReadinessProbe, "container 1")
+		require.Equal(t, []string{
+			"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/cosmos",
+			"--grpc-addr", "localhost:9090", "--api-host", "http://localhost:1317",
+		}, pod.Spec.Containers[1].Command)
+	})
+
+	t.Run("max block age", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.PodTemplate.Probes.MaxBlockAge = &metav1.Duration{Duration: 90 * time.Second}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{
+			"/manager", "healthcheck", "--rpc-host", "http://localhost:26657", "--home", "/home/operator/cosmos", "--max-block-age", "1m30s",
+		}, pod.Spec.Containers[1].Command)
+	})
+
+	t.Run("liveness probes", func(t *testing.T) {
+		crd := defaultCRD()
+		pod, err := NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.Nil(t, pod.Spec.Containers[0].LivenessProbe)
+		require.Nil(t, pod.Spec.Containers[0].StartupProbe)
+
+		crd.Spec.PodTemplate.Probes.Liveness = &cosmosv1.FullNodeLivenessSpec{}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		want := &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/livez",
+					Port:   intstr.FromInt(1251),
+					Scheme: "HTTP",
+				},
+			},
+			TimeoutSeconds:   10,
+			PeriodSeconds:    10,
+			SuccessThreshold: 1,
+			FailureThreshold: 12,
+		}
+		require.Equal(t, want, pod.Spec.Containers[0].LivenessProbe)
+
+		startup := pod.Spec.Containers[0].StartupProbe
+		require.Equal(t, "/startupz", startup.HTTPGet.Path)
+		require.EqualValues(t, 360, startup.FailureThreshold)
+
+		crd.Spec.PodTemplate.Probes.Liveness = &cosmosv1.FullNodeLivenessSpec{
+			StartupTimeout: &metav1.Duration{Duration: 25 * time.Second},
+			FailureTimeout: &metav1.Duration{Duration: time.Second},
+		}
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.EqualValues(t, 1, pod.Spec.Containers[0].LivenessProbe.FailureThreshold)
+		require.EqualValues(t, 3, pod.Spec.Containers[0].StartupProbe.FailureThreshold)
+
+		crd.Spec.PodTemplate.Probes.Strategy = cosmosv1.FullNodeProbeStrategyNone
+		pod, err = NewPodBuilder(&crd).WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		require.Nil(t, pod.Spec.Containers[0].LivenessProbe)
+		require.Nil(t, pod.Spec.Containers[0].StartupProbe)
 	})
 
 	t.Run("strategic merge fields", func(t *testing.T) {
@@ -760,6 +977,91 @@ gaiad start --home /home/operator/cosmos`
 		require.Equal(t, "new-init", pod2.Spec.InitContainers[2].Name)
 		require.Equal(t, "new-init:latest", pod2.Spec.InitContainers[2].Image)
 	})
+
+	t.Run("chain spec version node overrides", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.ChainSpec.AdditionalStartArgs = []string{"--foo", "bar"}
+		crd.Spec.PodTemplate.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		}
+		crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+			{Image: "image:v1.0.0"},
+			{
+				UpgradeHeight:       100,
+				Image:               "image:v2.0.0",
+				AdditionalStartArgs: []string{"--new-flag"},
+				Env: []corev1.EnvVar{
+					{Name: "HOME", Value: "/new/home"},
+					{Name: "NEW_VAR", Value: "new"},
+				},
+				Resources: &resources,
+			},
+		}
+		crd.Status.Height = map[string]uint64{"osmosis-0": 99, "osmosis-1": 100}
+
+		builder := NewPodBuilder(&crd)
+		pod0, err := builder.WithOrdinal(0).Build()
+		require.NoError(t, err)
+		pod1, err := builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+
+		node0, node1 := pod0.Spec.Containers[0], pod1.Spec.Containers[0]
+
+		require.Equal(t, []string{"start", "--home", "/home/operator/cosmos", "--foo", "bar"}, node0.Args)
+		require.Equal(t, []string{"start", "--home", "/home/operator/cosmos", "--foo", "bar", "--new-flag"}, node1.Args)
+		require.Equal(t, node0.Command, node1.Command)
+
+		require.Equal(t, envVars(&crd), node0.Env)
+		require.Len(t, node1.Env, len(node0.Env)+1)
+		require.Contains(t, node1.Env, corev1.EnvVar{Name: "HOME", Value: "/new/home"})
+		require.Equal(t, corev1.EnvVar{Name: "NEW_VAR", Value: "new"}, node1.Env[len(node1.Env)-1])
+
+		require.Equal(t, crd.Spec.PodTemplate.Resources, node0.Resources)
+		require.Equal(t, resources, node1.Resources)
+
+		// Other containers are unchanged.
+		require.Equal(t, pod0.Spec.InitContainers[1].Env, pod1.Spec.InitContainers[1].Env)
+	})
+
+	t.Run("sentry chain spec version start args", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.Type = cosmosv1.Sentry
+		crd.Spec.ChainSpec.Binary = "gaiad"
+		crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+			{Image: "image:v1.0.0", AdditionalStartArgs: []string{"--new-flag"}},
+		}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"sh"}, pod.Spec.Containers[0].Command)
+		require.Equal(t, []string{"-c", "sleep 10\ngaiad start --home /home/operator/cosmos --new-flag"}, pod.Spec.Containers[0].Args)
+	})
+
+	t.Run("containers with chain spec upgrade images", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.PodTemplate.Image = "image:v1.0.0"
+		crd.Spec.ChainSpec.UpgradeImages = map[string]string{"v2": "image:v2.0.0"}
+		crd.Status.Height = map[string]uint64{"osmosis-0": 100}
+		crd.Status.Upgrades = map[string]cosmosv1.UpgradeStatus{
+			"osmosis-0": {Name: "v2", Height: 100, Pending: true},
+		}
+
+		builder := NewPodBuilder(&crd)
+		pod0, err := builder.WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		containers := lo.SliceToMap(pod0.Spec.Containers, func(c corev1.Container) (string, corev1.Container) { return c.Name, c })
+		require.ElementsMatch(t, []string{"node", "healthcheck", "version-check-interval"}, lo.Keys(containers))
+		require.Equal(t, "image:v2.0.0", containers["node"].Image)
+
+		pod1, err := builder.WithOrdinal(1).Build()
+		require.NoError(t, err)
+		require.Equal(t, "image:v1.0.0", pod1.Spec.Containers[0].Image)
+	})
 }
 
 func TestChainHomeDir(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	"github.com/strangelove-ventures/cosmos-operator/internal/diff"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
@@ -116,7 +117,29 @@ func (pc PodControl) Reconcile(
 		return true, nil
 	}
 
-	diffedUpdates := diffed.Updates()
+	// Pods entering or leaving debug mode are excluded from rollouts, so replace them immediately.
+	var (
+		diffedUpdates []*corev1.Pod
+		debugUpdates  int
+		debugPods     = lo.CountBy(pods.Items, func(pod corev1.Pod) bool { return isDebugPod(&pod) })
+	)
+	for _, update := range diffed.Updates() {
+		wasDebug := lo.ContainsBy(pods.Items, func(pod corev1.Pod) bool { return pod.Name == update.Name && isDebugPod(&pod) })
+		if !wasDebug && !isDebugPod(update) {
+			diffedUpdates = append(diffedUpdates, update)
+			continue
+		}
+		reporter.Info("Deleting debug pod for update", "name", update.Name)
+		if err := pc.client.Delete(ctx, update, client.PropagationPolicy(metav1.DeletePropagationForeground)); client.IgnoreNotFound(err) != nil {
+			return true, kube.TransientError(fmt.Errorf("update debug pod %q: %w", update.Name, err))
+		}
+		invalidateCache = append(invalidateCache, update.Name)
+		debugUpdates++
+	}
+	if debugUpdates > 0 {
+		return true, nil
+	}
+
 	if len(diffedUpdates) > 0 {
 		var (
 			updatedPods                      = 0
@@ -228,7 +251,9 @@ func (pc PodControl) Reconcile(
 			}
 		}
 
-		numUpdates := pc.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), ready)
+		// Debug pods are intentionally unavailable; do not count them against the rollout.
+		desired := max(int(crd.Spec.Replicas)-debugPods, 0)
+		numUpdates := pc.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, desired, ready)
 
 		if updatedPods == totalMainPodsToUpdate {
 			// All main pods are updated.
@@ -297,6 +297,76 @@ func TestPodControl_Reconcile(t *testing.T) {
 		require.Equal(t, 2, mClient.DeleteCount)
 	})
 
+	t.Run("debug pods", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Name = "hub"
+		crd.Namespace = namespace
+		crd.Spec.Replicas = 3
+
+		pods, err := BuildPods(&crd, nil)
+		require.NoError(t, err)
+		mClient := newMockPodClient(diff.New(nil, pods).Creates())
+
+		syncInfo := map[string]*cosmosv1.SyncInfoPodStatus{
+			"hub-0": {InSync: ptr(true)},
+			"hub-1": {InSync: ptr(true)},
+			"hub-2": {InSync: ptr(true)},
+		}
+
+		control := NewPodControl(mClient, nil)
+		control.computeRollout = func(*intstr.IntOrString, int, int) int {
+			panic("should not be called")
+		}
+
+		// Entering debug mode bypasses the rollout.
+		crd.Spec.InstanceOverrides = map[string]cosmosv1.InstanceOverridesSpec{
+			"hub-1": {Debug: &cosmosv1.InstanceDebugSpec{}},
+		}
+		requeue, err := control.Reconcile(ctx, nopReporter, &crd, nil, syncInfo)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Equal(t, 1, mClient.DeleteCount)
+
+		debugPods, err := BuildPods(&crd, nil)
+		require.NoError(t, err)
+		existing := diff.New(nil, pods).Creates()
+		existing[1] = diff.New(nil, debugPods).Creates()[1]
+		require.True(t, isDebugPod(existing[1]))
+		mClient.setPods(existing)
+
+		syncInfo["hub-1"] = &cosmosv1.SyncInfoPodStatus{Error: ptr("connection refused")}
+
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd, nil, syncInfo)
+		require.NoError(t, err)
+		require.False(t, requeue)
+		require.Equal(t, 1, mClient.DeleteCount)
+
+		// Debug pods are replaced before the rollout.
+		crd.Spec.PodTemplate.Image = "new-image"
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd, nil, syncInfo)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Equal(t, 2, mClient.DeleteCount)
+
+		// Debug pod replaced; remaining pods roll out. Debug pods do not count against the rollout.
+		debugPods, err = BuildPods(&crd, nil)
+		require.NoError(t, err)
+		existing[1] = diff.New(nil, debugPods).Creates()[1]
+		mClient.setPods(existing)
+		var rolloutCalled bool
+		control.computeRollout = func(maxUnavail *intstr.IntOrString, desired, ready int) int {
+			rolloutCalled = true
+			require.Equal(t, 2, desired)
+			require.Equal(t, 2, ready)
+			return kube.ComputeRollout(maxUnavail, desired, ready)
+		}
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd, nil, syncInfo)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.True(t, rolloutCalled)
+		require.Equal(t, 3, mClient.DeleteCount)
+	})
+
 	t.Run("rollout phase with additional pods", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Name = "hub"
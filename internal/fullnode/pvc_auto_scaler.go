@@ -3,29 +3,40 @@ package fullnode
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"time"
 
+	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	offlineResizeDelay = 5 * time.Minute
+)
+
 type StatusSyncer interface {
 	SyncUpdate(ctx context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error
 }
 
 type PVCAutoScaler struct {
-	client StatusSyncer
-	now    func() time.Time
+	client         StatusSyncer
+	now            func() time.Time
+	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int
 }
 
 func NewPVCAutoScaler(client StatusSyncer) *PVCAutoScaler {
 	return &PVCAutoScaler{
-		client: client,
-		now:    time.Now,
+		client:         client,
+		now:            time.Now,
+		computeRollout: kube.ComputeRollout,
 	}
 }
 
@@ -35,16 +46,23 @@ func NewPVCAutoScaler(client StatusSyncer) *PVCAutoScaler {
 //
 // Returns true if the status was patched.
 //
+// If spec.predictive is set, PVCs also resize when their projected time until full is less than the horizon.
+// The new size then covers the projected usage for the target days.
+//
 // Returns false and does not patch if:
 // 1. The PVCs do not need resizing
 // 2. The status already has >= calculated size.
 // 3. The maximum size has been reached. It will patch up to the maximum size.
+// 4. The PVC's StorageClass does not allow volume expansion.
+// 5. A previous expansion has not completed or the cooldown since the last request has not elapsed.
+// 6. The maximum increases per day has been reached.
 //
 // Returns an error if patching unsuccessful.
 func (scaler PVCAutoScaler) SignalPVCResize(ctx context.Context, crd *cosmosv1.CosmosFullNode, results []PVCDiskUsage) (bool, error) {
 	var (
-		spec    = crd.Spec.SelfHeal.PVCAutoScale
-		trigger = int(spec.UsedSpacePercentage)
+		spec     = crd.Spec.SelfHeal.PVCAutoScale
+		trigger  = int(spec.UsedSpacePercentage)
+		cooldown = durationOrDefault(spec.Cooldown, 0)
 	)
 
 	var joinedErr error
@@ -56,21 +74,39 @@ func (scaler PVCAutoScaler) SignalPVCResize(ctx context.Context, crd *cosmosv1.C
 	now := metav1.NewTime(scaler.now())
 
 	for _, pvc := range results {
-		if pvc.PercentUsed < trigger {
+		predicted := predictiveResize(spec.Predictive, pvc)
+		if pvc.PercentUsed < trigger && !predicted {
 			// no need to expand
 			continue
 		}
+		if pvc.Resizing || pvcAutoScaleBlocked(crd, pvc, now.Time) != nil {
+			continue
+		}
+		if prev := status[pvc.Name]; prev != nil && now.Sub(prev.RequestedAt.Time) < cooldown {
+			continue
+		}
 
-		newSize, err := scaler.calcNextCapacity(pvc.Capacity, spec.IncreaseQuantity)
+		newSize, err := calcNextCapacity(pvc.Capacity, spec.IncreaseQuantity)
 		if err != nil {
 			joinedErr = errors.Join(joinedErr, err)
 			continue
 		}
+		if predicted {
+			if target := predictiveCapacity(spec, pvc); target.Cmp(newSize) > 0 {
+				newSize = target
+			}
+		}
 
 		if status != nil {
-			if pvcStatus, ok := status[pvc.Name]; ok && pvcStatus.RequestedSize.Value() == newSize.Value() {
-				// already requested
-				continue
+			if pvcStatus, ok := status[pvc.Name]; ok {
+				if pvcStatus.RequestedSize.Value() == newSize.Value() {
+					// already requested
+					continue
+				}
+				if predicted && pvcStatus.RequestedSize.Cmp(pvc.Capacity) > 0 {
+					// resize in progress; the projection changes as samples arrive
+					continue
+				}
 			}
 		}
 
@@ -87,8 +123,9 @@ func (scaler PVCAutoScaler) SignalPVCResize(ctx context.Context, crd *cosmosv1.C
 		}
 
 		patches[pvc.Name] = &cosmosv1.PVCAutoScaleStatus{
-			RequestedSize: newSize,
-			RequestedAt:   now,
+			RequestedSize:  newSize,
+			RequestedAt:    now,
+			RecentRequests: append(recentPVCRequests(status[pvc.Name], now.Time), now),
 		}
 	}
 
@@ -107,7 +144,81 @@ func (scaler PVCAutoScaler) SignalPVCResize(ctx context.Context, crd *cosmosv1.C
 	}))
 }
 
-func (scaler PVCAutoScaler) calcNextCapacity(current resource.Quantity, increase string) (resource.Quantity, error) {
+// pvcAutoScaleBlocked returns why the PVC cannot be expanded if it needs expansion. Returns nil if the PVC does not
+// need expansion or an expansion is in progress.
+func pvcAutoScaleBlocked(crd *cosmosv1.CosmosFullNode, pvc PVCDiskUsage, now time.Time) *pvcFailure {
+	spec := crd.Spec.SelfHeal.PVCAutoScale
+	if pvc.Resizing || (pvc.PercentUsed < int(spec.UsedSpacePercentage) && !predictiveResize(spec.Predictive, pvc)) {
+		return nil
+	}
+	if pvc.ExpansionUnsupported {
+		return &pvcFailure{Reason: "ExpansionNotAllowed", Message: "storage class does not allow volume expansion"}
+	}
+	if max := spec.MaxSize; !max.IsZero() && pvc.Capacity.Cmp(max) >= 0 {
+		return &pvcFailure{Reason: "MaxSizeReached", Message: fmt.Sprintf("capacity reached max size %s", max.String())}
+	}
+	if spec.MaxIncreasesPerDay == nil {
+		return nil
+	}
+	if n := len(recentPVCRequests(crd.Status.SelfHealing.PVCAutoScale[pvc.Name], now)); n >= int(*spec.MaxIncreasesPerDay) {
+		return &pvcFailure{Reason: "DailyLimitReached", Message: fmt.Sprintf("reached %d increases in the last 24 hours", n)}
+	}
+	return nil
+}
+
+// recentPVCRequests returns the status's requests within the last 24 hours.
+func recentPVCRequests(status *cosmosv1.PVCAutoScaleStatus, now time.Time) []metav1.Time {
+	if status == nil {
+		return nil
+	}
+	return lo.Filter(status.RecentRequests, func(ts metav1.Time, _ int) bool {
+		return now.Sub(ts.Time) < 24*time.Hour
+	})
+}
+
+// OfflineResizePod returns a pod whose PVC has waited on a filesystem resize for several minutes and is safe to
+// delete so the volume is unmounted. Returns false if none.
+//
+// The PVC's FileSystemResizePending condition keeps its transition time until the recreated pod mounts the volume,
+// so pods are skipped while being deleted, if younger than the resize delay, or if recently restarted for a resize.
+// Deleting must not leave no other in-sync replica nor exceed the rollout strategy's max unavailable.
+func (scaler PVCAutoScaler) OfflineResizePod(crd *cosmosv1.CosmosFullNode, usage []PVCDiskUsage, coll cosmos.StatusCollection) (*corev1.Pod, bool) {
+	var (
+		now       = scaler.now()
+		pods      = lo.SliceToMap(coll.Pods(), func(pod *corev1.Pod) (string, *corev1.Pod) { return pod.Name, pod })
+		available = kube.AvailablePods(coll.SyncedPods(), 5*time.Second, now)
+	)
+	pending := lo.Filter(usage, func(pvc PVCDiskUsage, _ int) bool {
+		if pvc.FileSystemResizePendingSince.IsZero() || now.Sub(pvc.FileSystemResizePendingSince) < offlineResizeDelay {
+			return false
+		}
+		pod := pods[pvc.PodName]
+		if pod == nil || pod.DeletionTimestamp != nil || now.Sub(pod.CreationTimestamp.Time) < offlineResizeDelay {
+			return false
+		}
+		if restarted, ok := crd.Status.SelfHealing.OfflineResizeRestarts[pvc.PodName]; ok && now.Sub(restarted.Time) < offlineResizeDelay {
+			return false
+		}
+		return disruptionBlocked(crd, available, pvc.PodName, scaler.computeRollout) == ""
+	})
+	if len(pending) == 0 {
+		return nil, false
+	}
+	return pods[lo.MinBy(pending, func(a, b PVCDiskUsage) bool { return a.PodName < b.PodName }).PodName], true
+}
+
+// RecordOfflineResizeRestart records in status that the pod is deleted to complete an offline filesystem resize.
+func (scaler PVCAutoScaler) RecordOfflineResizeRestart(ctx context.Context, crd *cosmosv1.CosmosFullNode, podName string) error {
+	now := metav1.NewTime(scaler.now())
+	return scaler.client.SyncUpdate(ctx, client.ObjectKeyFromObject(crd), func(status *cosmosv1.FullNodeStatus) {
+		if status.SelfHealing.OfflineResizeRestarts == nil {
+			status.SelfHealing.OfflineResizeRestarts = make(map[string]metav1.Time)
+		}
+		status.SelfHealing.OfflineResizeRestarts[podName] = now
+	})
+}
+
+func calcNextCapacity(current resource.Quantity, increase string) (resource.Quantity, error) {
 	var (
 		merr     error
 		quantity resource.Quantity
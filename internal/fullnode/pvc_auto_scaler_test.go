@@ -9,8 +9,12 @@ import (
 
 	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -282,4 +286,278 @@ func TestPVCAutoScaler_SignalPVCResize(t *testing.T) {
 		require.Error(t, err)
 		require.EqualError(t, err, "boom")
 	})
+	t.Run("predictive", func(t *testing.T) {
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "name"
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			PVCAutoScale: &cosmosv1.PVCAutoScaleSpec{
+				UsedSpacePercentage: 80,
+				IncreaseQuantity:    "10Gi",
+				Predictive: &cosmosv1.PVCPredictiveScaleSpec{
+					Horizon:    &metav1.Duration{Duration: 48 * time.Hour},
+					TargetDays: ptr(int32(10)),
+				},
+			},
+		}
+
+		const gib = 1 << 30
+		var (
+			capacity = resource.MustParse("100Gi")
+			// 2Gi per day
+			fast = &DiskGrowth{BytesPerSecond: 2 * gib / 86400.0, TimeToFull: 40 * time.Hour}
+			slow = &DiskGrowth{BytesPerSecond: 1, TimeToFull: 72 * time.Hour}
+		)
+
+		for _, tt := range []struct {
+			Name   string
+			Usage  PVCDiskUsage
+			Status *cosmosv1.PVCAutoScaleStatus
+			Want   *resource.Quantity
+		}{
+			{"within horizon covers target days", PVCDiskUsage{PercentUsed: 70, Capacity: capacity, UsedBytes: 70 * gib, Growth: fast}, nil, ptr(resource.MustParse("113Gi"))},
+			{"within horizon at least increase quantity", PVCDiskUsage{PercentUsed: 50, Capacity: resource.MustParse("60Gi"), UsedBytes: 40 * gib, Growth: fast}, nil, ptr(resource.MustParse("75Gi"))},
+			{"outside horizon", PVCDiskUsage{PercentUsed: 50, Capacity: capacity, UsedBytes: 50 * gib, Growth: slow}, nil, nil},
+			{"no growth", PVCDiskUsage{PercentUsed: 50, Capacity: capacity, UsedBytes: 50 * gib}, nil, nil},
+			{"resize in progress", PVCDiskUsage{PercentUsed: 50, Capacity: capacity, UsedBytes: 60 * gib, Growth: fast},
+				&cosmosv1.PVCAutoScaleStatus{RequestedSize: resource.MustParse("110Gi")}, nil},
+			{"threshold", PVCDiskUsage{PercentUsed: 80, Capacity: capacity, UsedBytes: 80 * gib, Growth: slow}, nil, ptr(resource.MustParse("110Gi"))},
+		} {
+			tt.Usage.Name = "pvc-name-0"
+			crd.Status.SelfHealing.PVCAutoScale = nil
+			if tt.Status != nil {
+				crd.Status.SelfHealing.PVCAutoScale = map[string]*cosmosv1.PVCAutoScaleStatus{"pvc-name-0": tt.Status}
+			}
+
+			var patched *resource.Quantity
+			scaler := NewPVCAutoScaler(mockStatusSyncer(func(_ context.Context, _ client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+				var status cosmosv1.FullNodeStatus
+				update(&status)
+				patched = &status.SelfHealing.PVCAutoScale["pvc-name-0"].RequestedSize
+				return nil
+			}))
+			got, err := scaler.SignalPVCResize(ctx, &crd, []PVCDiskUsage{tt.Usage})
+
+			require.NoError(t, err, tt.Name)
+			require.Equal(t, tt.Want != nil, got, tt.Name)
+			if tt.Want != nil {
+				require.Equal(t, tt.Want.Value(), patched.Value(), tt.Name)
+			}
+		}
+	})
+
+	t.Run("safeguards", func(t *testing.T) {
+		stubNow := time.Now()
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "name"
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			PVCAutoScale: &cosmosv1.PVCAutoScaleSpec{
+				UsedSpacePercentage: 80,
+				IncreaseQuantity:    "10Gi",
+				Cooldown:            &metav1.Duration{Duration: time.Hour},
+				MaxIncreasesPerDay:  ptr(int32(2)),
+			},
+		}
+		recent := []metav1.Time{metav1.NewTime(stubNow.Add(-25 * time.Hour)), metav1.NewTime(stubNow.Add(-2 * time.Hour))}
+
+		for _, tt := range []struct {
+			Name   string
+			Usage  PVCDiskUsage
+			Status *cosmosv1.PVCAutoScaleStatus
+			Want   bool
+		}{
+			{"expansion not allowed", PVCDiskUsage{PercentUsed: 90, ExpansionUnsupported: true}, nil, false},
+			{"resizing", PVCDiskUsage{PercentUsed: 90, Resizing: true}, nil, false},
+			{"within cooldown", PVCDiskUsage{PercentUsed: 90},
+				&cosmosv1.PVCAutoScaleStatus{RequestedAt: metav1.NewTime(stubNow.Add(-59 * time.Minute))}, false},
+			{"daily limit", PVCDiskUsage{PercentUsed: 90},
+				&cosmosv1.PVCAutoScaleStatus{RequestedAt: metav1.NewTime(stubNow.Add(-time.Hour)), RecentRequests: append(recent, metav1.NewTime(stubNow.Add(-time.Hour)))}, false},
+			{"after cooldown", PVCDiskUsage{PercentUsed: 90},
+				&cosmosv1.PVCAutoScaleStatus{RequestedAt: metav1.NewTime(stubNow.Add(-2 * time.Hour)), RecentRequests: recent}, true},
+		} {
+			tt.Usage.Name = "pvc-name-0"
+			tt.Usage.Capacity = resource.MustParse("100Gi")
+			crd.Status.SelfHealing.PVCAutoScale = nil
+			if tt.Status != nil {
+				crd.Status.SelfHealing.PVCAutoScale = map[string]*cosmosv1.PVCAutoScaleStatus{"pvc-name-0": tt.Status}
+			}
+
+			var patched *cosmosv1.PVCAutoScaleStatus
+			scaler := NewPVCAutoScaler(mockStatusSyncer(func(_ context.Context, _ client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+				var status cosmosv1.FullNodeStatus
+				update(&status)
+				patched = status.SelfHealing.PVCAutoScale["pvc-name-0"]
+				return nil
+			}))
+			scaler.now = func() time.Time { return stubNow }
+			got, err := scaler.SignalPVCResize(ctx, &crd, []PVCDiskUsage{tt.Usage})
+
+			require.NoError(t, err, tt.Name)
+			require.Equal(t, tt.Want, got, tt.Name)
+			if tt.Want {
+				// Requests older than 24 hours are discarded.
+				want := []metav1.Time{recent[1], metav1.NewTime(stubNow)}
+				require.Equal(t, want, patched.RecentRequests)
+			}
+		}
+	})
+
+	t.Run("no cooldown or daily limit by default", func(t *testing.T) {
+		stubNow := time.Now()
+		var crd cosmosv1.CosmosFullNode
+		crd.Name = "name"
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+			PVCAutoScale: &cosmosv1.PVCAutoScaleSpec{
+				UsedSpacePercentage: 80,
+				IncreaseQuantity:    "10Gi",
+			},
+		}
+		crd.Status.SelfHealing.PVCAutoScale = map[string]*cosmosv1.PVCAutoScaleStatus{"pvc-name-0": {
+			RequestedAt:    metav1.NewTime(stubNow.Add(-time.Minute)),
+			RecentRequests: lo.Times(10, func(i int) metav1.Time { return metav1.NewTime(stubNow.Add(-time.Duration(i+1) * time.Minute)) }),
+		}}
+
+		scaler := NewPVCAutoScaler(mockStatusSyncer(func(context.Context, client.ObjectKey, func(status *cosmosv1.FullNodeStatus)) error {
+			return nil
+		}))
+		scaler.now = func() time.Time { return stubNow }
+		got, err := scaler.SignalPVCResize(ctx, &crd, []PVCDiskUsage{
+			{Name: "pvc-name-0", PercentUsed: 90, Capacity: resource.MustParse("100Gi")},
+		})
+
+		require.NoError(t, err)
+		require.True(t, got)
+	})
+}
+
+func TestPVCAutoScaleCondition(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	crd := defaultCRD()
+	crd.Generation = 3
+	crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{
+		PVCAutoScale: &cosmosv1.PVCAutoScaleSpec{
+			UsedSpacePercentage: 80,
+			IncreaseQuantity:    "10%",
+			MaxSize:             resource.MustParse("1Ti"),
+			MaxIncreasesPerDay:  ptr(int32(4)),
+		},
+	}
+	crd.Status.SelfHealing.PVCAutoScale = map[string]*cosmosv1.PVCAutoScaleStatus{
+		"pvc-osmosis-2": {RecentRequests: lo.Times(4, func(i int) metav1.Time { return metav1.NewTime(now.Add(-time.Duration(i) * time.Hour)) })},
+	}
+
+	usage := []PVCDiskUsage{
+		{Name: "pvc-osmosis-0", PercentUsed: 90, Capacity: resource.MustParse("100Gi"), ExpansionUnsupported: true},
+		{Name: "pvc-osmosis-1", PercentUsed: 90, Capacity: resource.MustParse("1Ti")},
+		{Name: "pvc-osmosis-2", PercentUsed: 90, Capacity: resource.MustParse("100Gi")},
+		// Not blocked.
+		{Name: "pvc-osmosis-3", PercentUsed: 50, Capacity: resource.MustParse("100Gi"), ExpansionUnsupported: true},
+		{Name: "pvc-osmosis-4", PercentUsed: 90, Capacity: resource.MustParse("100Gi"), ExpansionUnsupported: true, Resizing: true},
+		{Name: "pvc-osmosis-5", PercentUsed: 90, Capacity: resource.MustParse("100Gi")},
+	}
+
+	got := PVCAutoScaleCondition(&crd, usage, now)
+	require.Equal(t, cosmosv1.ConditionPVCAutoScaleBlocked, got.Type)
+	require.Equal(t, metav1.ConditionTrue, got.Status)
+	require.EqualValues(t, 3, got.ObservedGeneration)
+	require.Equal(t, "ExpansionNotAllowed", got.Reason)
+	require.Equal(t, "pvc-osmosis-0: storage class does not allow volume expansion; pvc-osmosis-1: capacity reached max size 1Ti; "+
+		"pvc-osmosis-2: reached 4 increases in the last 24 hours", got.Message)
+
+	got = PVCAutoScaleCondition(&crd, usage[3:], now)
+	require.Equal(t, metav1.ConditionFalse, got.Status)
+	require.Equal(t, "NoFailures", got.Reason)
+}
+
+func TestPVCAutoScaler_OfflineResizePod(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	old := metav1.NewTime(now.Add(-time.Hour))
+
+	newColl := func(synced int, names ...string) cosmos.StatusCollection {
+		return lo.Map(names, func(name string, i int) cosmos.StatusItem {
+			pod := new(corev1.Pod)
+			pod.Name = name
+			pod.CreationTimestamp = old
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: old}}
+			item := cosmos.StatusItem{Pod: pod}
+			item.Status.Result.SyncInfo.CatchingUp = i >= synced
+			return item
+		})
+	}
+
+	newScaler := func() *PVCAutoScaler {
+		scaler := NewPVCAutoScaler(nil)
+		scaler.now = func() time.Time { return now }
+		scaler.computeRollout = func(*intstr.IntOrString, int, int) int { return 1 }
+		return scaler
+	}
+
+	crd := defaultCRD()
+	crd.Spec.Replicas = 4
+	names := []string{"osmosis-0", "osmosis-1", "osmosis-2", "osmosis-3"}
+	usage := []PVCDiskUsage{
+		{PodName: "osmosis-0"},
+		{PodName: "osmosis-1", FileSystemResizePendingSince: now.Add(-4 * time.Minute)},
+		{PodName: "osmosis-3", FileSystemResizePendingSince: now.Add(-time.Hour)},
+		{PodName: "osmosis-2", FileSystemResizePendingSince: now.Add(-5 * time.Minute)},
+	}
+
+	got, ok := newScaler().OfflineResizePod(&crd, usage, newColl(4, names...))
+	require.True(t, ok)
+	require.Equal(t, "osmosis-2", got.Name)
+
+	_, ok = newScaler().OfflineResizePod(&crd, usage[:2], newColl(4, names...))
+	require.False(t, ok)
+
+	t.Run("recreated, deleting or recently restarted pods", func(t *testing.T) {
+		coll := newColl(4, names...)
+		coll[2].Pod.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+		coll[3].Pod.DeletionTimestamp = ptr(metav1.NewTime(now))
+
+		_, ok := newScaler().OfflineResizePod(&crd, usage, coll)
+		require.False(t, ok)
+
+		crd := crd.DeepCopy()
+		crd.Status.SelfHealing.OfflineResizeRestarts = map[string]metav1.Time{"osmosis-2": metav1.NewTime(now.Add(-time.Minute))}
+		got, ok := newScaler().OfflineResizePod(crd, usage, newColl(4, names...))
+		require.True(t, ok)
+		require.Equal(t, "osmosis-3", got.Name)
+	})
+
+	t.Run("availability", func(t *testing.T) {
+		// Only osmosis-2 is in sync.
+		_, ok := newScaler().OfflineResizePod(&crd, usage, newColl(1, "osmosis-2", "osmosis-0", "osmosis-1", "osmosis-3"))
+		require.True(t, ok)
+		_, ok = newScaler().OfflineResizePod(&crd, usage[3:], newColl(1, "osmosis-2", "osmosis-0", "osmosis-1", "osmosis-3"))
+		require.False(t, ok)
+
+		scaler := newScaler()
+		scaler.computeRollout = func(maxUnavail *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, 4, desired)
+			require.Equal(t, 4, ready)
+			return 0
+		}
+		_, ok = scaler.OfflineResizePod(&crd, usage, newColl(4, names...))
+		require.False(t, ok)
+	})
+}
+
+func TestPVCAutoScaler_RecordOfflineResizeRestart(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	crd := defaultCRD()
+	var got cosmosv1.FullNodeStatus
+	scaler := NewPVCAutoScaler(mockStatusSyncer(func(_ context.Context, key client.ObjectKey, update func(status *cosmosv1.FullNodeStatus)) error {
+		require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, key)
+		update(&got)
+		return nil
+	}))
+	scaler.now = func() time.Time { return now }
+
+	require.NoError(t, scaler.RecordOfflineResizeRestart(context.Background(), &crd, "osmosis-1"))
+	require.Equal(t, map[string]metav1.Time{"osmosis-1": metav1.NewTime(now)}, got.SelfHealing.OfflineResizeRestarts)
 }
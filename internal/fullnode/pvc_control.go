@@ -2,7 +2,10 @@ package fullnode
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	"github.com/samber/lo"
@@ -20,13 +23,17 @@ import (
 // Unlike StatefulSet, PVCControl will update volumes by deleting and recreating volumes.
 type PVCControl struct {
 	client               Client
-	recentVolumeSnapshot func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error)
+	collector            StatusCollector
+	now                  func() time.Time
+	recentVolumeSnapshot func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, filters ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error)
 }
 
 // NewPVCControl returns a valid PVCControl
-func NewPVCControl(client Client) PVCControl {
+func NewPVCControl(client Client, collector StatusCollector) PVCControl {
 	return PVCControl{
 		client:               client,
+		collector:            collector,
+		now:                  time.Now,
 		recentVolumeSnapshot: kube.RecentVolumeSnapshot,
 	}
 }
@@ -49,7 +56,11 @@ func (control PVCControl) Reconcile(ctx context.Context, reporter kube.Reporter,
 
 	var currentPVCs = ptrSlice(vols.Items)
 
+	control.clearSiblingClones(crd, currentPVCs)
+
 	dataSources := make(map[int32]*dataSource)
+	// PVCs waiting on a sibling pod to be deleted before they can be cloned.
+	pending := make(map[string]bool)
 	if len(currentPVCs) < int(crd.Spec.Replicas) {
 		for i := crd.Spec.Ordinals.Start; i < crd.Spec.Ordinals.Start+crd.Spec.Replicas; i++ {
 			name := pvcName(crd, i)
@@ -61,7 +72,11 @@ func (control PVCControl) Reconcile(ctx context.Context, reporter kube.Reporter,
 				}
 			}
 			if !found {
-				ds := control.findDataSource(ctx, reporter, crd, i)
+				ds, wait := control.findDataSource(ctx, reporter, crd, i, currentPVCs)
+				if wait {
+					pending[name] = true
+					continue
+				}
 				if ds == nil {
 					ds = &dataSource{
 						size: crd.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage],
@@ -75,9 +90,10 @@ func (control PVCControl) Reconcile(ctx context.Context, reporter kube.Reporter,
 	var (
 		wantPVCs = BuildPVCs(crd, dataSources, currentPVCs)
 		diffed   = diff.New(currentPVCs, wantPVCs)
+		creates  = lo.Filter(diffed.Creates(), func(pvc *corev1.PersistentVolumeClaim, _ int) bool { return !pending[pvc.Name] })
 	)
 
-	for _, pvc := range diffed.Creates() {
+	for _, pvc := range creates {
 		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
 
 		reporter.Info(
@@ -106,24 +122,59 @@ func (control PVCControl) Reconcile(ctx context.Context, reporter kube.Reporter,
 		deletes = len(diffed.Deletes())
 	}
 
-	if deletes+len(diffed.Creates()) > 0 {
+	if deletes+len(creates)+len(pending) > 0 {
 		// Scaling happens first; then updates. So requeue to handle updates after scaling finished.
 		return true, nil
 	}
 
-	if len(diffed.Updates()) == 0 {
-		return false, nil
+	failures := make(map[string]pvcFailure)
+	for _, pvc := range currentPVCs {
+		if msg := pvcResizeError(pvc); msg != "" {
+			failures[pvc.Name] = pvcFailure{Reason: "ResizeError", Message: msg}
+		}
+	}
+	requeue := control.patchSizes(ctx, reporter, diffed.Updates(), currentPVCs, failures)
+	control.setResizeCondition(reporter, crd, failures)
+	return requeue, nil
+}
+
+// patchSizes patches the storage size of the updated PVCs once all PVCs are bound. Patches increasing the size of
+// a PVC whose StorageClass does not allow volume expansion are skipped. Failures are added to failures.
+// The bool return value, if true, indicates the controller should requeue the request.
+func (control PVCControl) patchSizes(
+	ctx context.Context,
+	reporter kube.Reporter,
+	updates []*corev1.PersistentVolumeClaim,
+	currentPVCs []*corev1.PersistentVolumeClaim,
+	failures map[string]pvcFailure,
+) bool {
+	if len(updates) == 0 {
+		return false
 	}
 
 	if _, unbound := lo.Find(currentPVCs, func(pvc *corev1.PersistentVolumeClaim) bool {
 		return pvc.Status.Phase != corev1.ClaimBound
 	}); unbound {
-		return true, nil
+		return true
 	}
 
 	// PVCs have many immutable fields, so only update the storage size.
-	for _, pvc := range diffed.Updates() {
+	for _, pvc := range updates {
 		size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if current, ok := lo.Find(currentPVCs, func(c *corev1.PersistentVolumeClaim) bool { return c.Name == pvc.Name }); ok {
+			currentSize := current.Spec.Resources.Requests[corev1.ResourceStorage]
+			allowed, err := expansionAllowed(ctx, control.client, current)
+			if err != nil {
+				// The API server rejects the patch if expansion is not allowed.
+				reporter.Error(err, "Failed to check pvc storage class", "name", pvc.Name)
+			} else if !allowed && size.Cmp(currentSize) > 0 {
+				failures[pvc.Name] = pvcFailure{
+					Reason:  "ExpansionNotAllowed",
+					Message: fmt.Sprintf("storage class %s does not allow volume expansion", lo.FromPtr(current.Spec.StorageClassName)),
+				}
+				continue
+			}
+		}
 		reporter.Info(
 			"Patching pvc",
 			"name", pvc.Name,
@@ -138,12 +189,21 @@ func (control PVCControl) Reconcile(ctx context.Context, reporter kube.Reporter,
 		}
 		if err := control.client.Patch(ctx, &patch, client.Merge); err != nil {
 			reporter.Error(err, "PVC patch failed", "name", pvc.Name)
-			reporter.RecordError("PVCPatchFailed", err)
+			failures[pvc.Name] = pvcFailure{Reason: "PatchFailed", Message: err.Error()}
 			continue
 		}
 	}
 
-	return false, nil
+	return false
+}
+
+// setResizeCondition sets the PVCResizeFailed condition on the crd's status.
+// Records an event only when the condition becomes true to avoid an event per reconcile.
+func (control PVCControl) setResizeCondition(reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, failures map[string]pvcFailure) {
+	cond := pvcFailureCondition(cosmosv1.ConditionPVCResizeFailed, crd.Generation, failures)
+	if setCondition(&crd.Status.Conditions, cond) {
+		reporter.RecordError("PVCResizeFailed", errors.New(cond.Message))
+	}
 }
 
 func (control PVCControl) shouldRetain(crd *cosmosv1.CosmosFullNode) bool {
@@ -159,15 +219,23 @@ type dataSource struct {
 	size resource.Quantity
 }
 
-func (control PVCControl) findDataSource(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, ordinal int32) *dataSource {
+// findDataSource returns the data source for a new PVC, if any. The bool return value, if true, indicates
+// the PVC should not be created yet because its data source is not ready.
+func (control PVCControl) findDataSource(
+	ctx context.Context,
+	reporter kube.Reporter,
+	crd *cosmosv1.CosmosFullNode,
+	ordinal int32,
+	currentPVCs []*corev1.PersistentVolumeClaim,
+) (*dataSource, bool) {
 	podName := instanceName(crd, ordinal)
 	if override, ok := crd.Spec.InstanceOverrides[podName]; ok {
 		if overrideTpl := override.VolumeClaimTemplate; overrideTpl != nil {
-			return control.findDataSourceWithPvcSpec(ctx, reporter, crd, *overrideTpl, ordinal)
+			return control.findDataSourceWithPvcSpec(ctx, reporter, crd, *overrideTpl, ordinal, currentPVCs)
 		}
 	}
 
-	return control.findDataSourceWithPvcSpec(ctx, reporter, crd, crd.Spec.VolumeClaimTemplate, ordinal)
+	return control.findDataSourceWithPvcSpec(ctx, reporter, crd, crd.Spec.VolumeClaimTemplate, ordinal, currentPVCs)
 }
 
 func (control PVCControl) findDataSourceWithPvcSpec(
@@ -176,41 +244,58 @@ func (control PVCControl) findDataSourceWithPvcSpec(
 	crd *cosmosv1.CosmosFullNode,
 	pvcSpec cosmosv1.PersistentVolumeClaimSpec,
 	ordinal int32,
-) *dataSource {
+	currentPVCs []*corev1.PersistentVolumeClaim,
+) (*dataSource, bool) {
 	if ds := pvcSpec.DataSource; ds != nil {
 		if ds.Kind == "VolumeSnapshot" && ds.APIGroup != nil && *ds.APIGroup == "snapshot.storage.k8s.io" {
 			var vs snapshotv1.VolumeSnapshot
 			if err := control.client.Get(ctx, client.ObjectKey{Namespace: crd.Namespace, Name: ds.Name}, &vs); err != nil {
 				reporter.Error(err, "Failed to get VolumeSnapshot for DataSource")
 				reporter.RecordError("DataSourceGetSnapshot", err)
-				return nil
+				return nil, false
 			}
 			return &dataSource{
 				ref:  ds,
 				size: *vs.Status.RestoreSize,
-			}
+			}, false
 		} else if ds.Kind == "PersistentVolumeClaim" && (ds.APIGroup == nil || *ds.APIGroup == "") {
 			var pvc corev1.PersistentVolumeClaim
 			if err := control.client.Get(ctx, client.ObjectKey{Namespace: crd.Namespace, Name: ds.Name}, &pvc); err != nil {
 				reporter.Error(err, "Failed to get PersistentVolumeClaim for DataSource")
 				reporter.RecordError("DataSourceGetPVC", err)
-				return nil
+				return nil, false
 			}
 			return &dataSource{
 				ref:  ds,
 				size: pvc.Status.Capacity["storage"],
-			}
+			}, false
 		} else {
 			err := fmt.Errorf("unsupported DataSource %s", ds.Kind)
 			reporter.Error(err, "Unsupported DataSource")
 			reporter.RecordError("DataSourceUnsupported", err)
-			return nil
+			return nil, false
 		}
 	}
 	spec := pvcSpec.AutoDataSource
 	if spec == nil {
-		return nil
+		return nil, false
 	}
+	if found := control.findVolumeSnapshot(ctx, reporter, crd, *spec, ordinal); found != nil {
+		return found, false
+	}
+	if spec.SiblingClone == nil {
+		return nil, false
+	}
+	return control.findSibling(ctx, reporter, crd, *spec.SiblingClone, ordinal, currentPVCs)
+}
+
+func (control PVCControl) findVolumeSnapshot(
+	ctx context.Context,
+	reporter kube.Reporter,
+	crd *cosmosv1.CosmosFullNode,
+	spec cosmosv1.AutoDataSource,
+	ordinal int32,
+) *dataSource {
 	selector := spec.VolumeSnapshotSelector
 	if len(selector) == 0 {
 		return nil
@@ -218,8 +303,20 @@ func (control PVCControl) findDataSourceWithPvcSpec(
 	if spec.MatchInstance {
 		selector[kube.InstanceLabel] = instanceName(crd, ordinal)
 	}
-	found, err := control.recentVolumeSnapshot(ctx, control.client, crd.Namespace, selector)
+	compatible := func(snapshot snapshotv1.VolumeSnapshot) bool {
+		if reason := incompatibleSnapshot(crd, snapshot); reason != "" {
+			reporter.Info("Skipping incompatible VolumeSnapshot for AutoDataSource", "volumeSnapshot", snapshot.Name, "reason", reason)
+			return false
+		}
+		return true
+	}
+	found, err := control.recentVolumeSnapshot(ctx, control.client, crd.Namespace, selector, compatible)
 	if err != nil {
+		if spec.SiblingClone != nil {
+			// Expected when bootstrapping before any VolumeSnapshots exist; sibling clone is the fallback.
+			reporter.Info("No VolumeSnapshot found for AutoDataSource; falling back to sibling clone", "error", err)
+			return nil
+		}
 		reporter.Error(err, "Failed to find VolumeSnapshot for AutoDataSource")
 		reporter.RecordError("AutoDataSourceFindSnapshot", err)
 		return nil
@@ -235,3 +332,168 @@ func (control PVCControl) findDataSourceWithPvcSpec(
 		size: *found.Status.RestoreSize,
 	}
 }
+
+// findSibling finds the PVC of an in-sync sibling to clone. If the sibling pod must be deleted prior to cloning,
+// it records the clone in the crd's status and returns true, indicating the PVC should be created once
+// the CosmosFullNode controller deletes the pod.
+func (control PVCControl) findSibling(
+	ctx context.Context,
+	reporter kube.Reporter,
+	crd *cosmosv1.CosmosFullNode,
+	spec cosmosv1.SiblingCloneSpec,
+	ordinal int32,
+	currentPVCs []*corev1.PersistentVolumeClaim,
+) (*dataSource, bool) {
+	var (
+		name = pvcName(crd, ordinal)
+		coll = control.collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+	)
+
+	// Drop clones whose source PVC is gone; other clones are unaffected.
+	for target, clone := range crd.Status.SiblingClones {
+		if lo.ContainsBy(currentPVCs, func(pvc *corev1.PersistentVolumeClaim) bool { return pvc.Name == clone.SourcePVC }) {
+			continue
+		}
+		err := fmt.Errorf("clone source pvc %s not found", clone.SourcePVC)
+		reporter.Error(err, "Failed to clone sibling PVC", "pvc", target)
+		reporter.RecordError("SiblingCloneMissingSource", err)
+		delete(crd.Status.SiblingClones, target)
+	}
+
+	// A clone is already in progress. Share the source pod, so we remove at most 1 pod at a time.
+	if len(crd.Status.SiblingClones) > 0 {
+		clone, ok := crd.Status.SiblingClones[name]
+		if !ok {
+			targets := lo.Keys(crd.Status.SiblingClones)
+			slices.Sort(targets)
+			shared := crd.Status.SiblingClones[targets[0]]
+			clone = cosmosv1.FullNodeSiblingCloneStatus{
+				SourcePod: shared.SourcePod,
+				SourcePVC: shared.SourcePVC,
+				StartedAt: metav1.NewTime(control.now()),
+			}
+			crd.Status.SiblingClones[name] = clone
+		}
+		if _, exists := lo.Find(coll.Pods(), func(pod *corev1.Pod) bool { return pod.Name == clone.SourcePod }); exists {
+			// Waiting for pod deletion.
+			return nil, true
+		}
+		source, _ := lo.Find(currentPVCs, func(pvc *corev1.PersistentVolumeClaim) bool { return pvc.Name == clone.SourcePVC })
+		reporter.RecordInfo("SiblingClone", fmt.Sprintf("Cloning PVC %s from sibling PVC %s", name, source.Name))
+		return pvcDataSource(source), false
+	}
+
+	minAvail := spec.MinAvailable
+	if minAvail <= 0 {
+		minAvail = 2
+	}
+	synced := kube.AvailablePods(coll.SyncedPods(), 5*time.Second, control.now())
+	if int32(len(synced)) < minAvail {
+		err := fmt.Errorf("%d or more pods must be in-sync to clone a sibling PVC, found %d in-sync", minAvail, len(synced))
+		reporter.Error(err, "Skipping sibling clone", "pvc", name)
+		reporter.RecordError("SiblingCloneUnavailable", err)
+		return nil, false
+	}
+
+	for _, pod := range synced {
+		source, ok := lo.Find(currentPVCs, func(pvc *corev1.PersistentVolumeClaim) bool {
+			return pvc.Name == PVCName(pod) && pvc.DeletionTimestamp == nil && pvc.Status.Phase == corev1.ClaimBound
+		})
+		if !ok {
+			continue
+		}
+		if spec.DeletePod {
+			if crd.Status.SiblingClones == nil {
+				crd.Status.SiblingClones = make(map[string]cosmosv1.FullNodeSiblingCloneStatus)
+			}
+			crd.Status.SiblingClones[name] = cosmosv1.FullNodeSiblingCloneStatus{
+				SourcePod: pod.Name,
+				SourcePVC: source.Name,
+				StartedAt: metav1.NewTime(control.now()),
+			}
+			reporter.RecordInfo("SiblingClone", fmt.Sprintf("Temporarily deleting pod %s to clone its PVC", pod.Name))
+			return nil, true
+		}
+		reporter.RecordInfo("SiblingClone", fmt.Sprintf("Cloning PVC %s from sibling PVC %s", name, source.Name))
+		return pvcDataSource(source), false
+	}
+
+	return nil, false
+}
+
+// clearSiblingClones removes clones from the crd's status once the new PVC is bound, signaling the
+// source pod can be restored.
+func (control PVCControl) clearSiblingClones(crd *cosmosv1.CosmosFullNode, currentPVCs []*corev1.PersistentVolumeClaim) {
+	for target := range crd.Status.SiblingClones {
+		found, ok := lo.Find(currentPVCs, func(pvc *corev1.PersistentVolumeClaim) bool { return pvc.Name == target })
+		if ok && found.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+		if !ok && control.wantsPVC(crd, target) {
+			continue
+		}
+		delete(crd.Status.SiblingClones, target)
+	}
+}
+
+func (control PVCControl) wantsPVC(crd *cosmosv1.CosmosFullNode, name string) bool {
+	for i := crd.Spec.Ordinals.Start; i < crd.Spec.Ordinals.Start+crd.Spec.Replicas; i++ {
+		if pvcName(crd, i) == name {
+			return !pvcDisabled(crd, i)
+		}
+	}
+	return false
+}
+
+func pvcDataSource(pvc *corev1.PersistentVolumeClaim) *dataSource {
+	return &dataSource{
+		ref: &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: pvc.Name,
+		},
+		size: pvc.Status.Capacity[corev1.ResourceStorage],
+	}
+}
+
+// incompatibleSnapshot returns a non-empty reason if the chain state recorded on the snapshot's annotations
+// cannot be used to bootstrap crd's instances. Snapshots lacking annotations are assumed compatible.
+func incompatibleSnapshot(crd *cosmosv1.CosmosFullNode, snapshot snapshotv1.VolumeSnapshot) string {
+	chainID := snapshot.Annotations[kube.SnapshotChainIDAnnotation]
+	if chainID != "" && chainID != crd.Spec.ChainSpec.ChainID {
+		return fmt.Sprintf("chain ID %q does not match %q", chainID, crd.Spec.ChainSpec.ChainID)
+	}
+
+	height := kube.VolumeSnapshotHeight(snapshot)
+	if !HasChainVersions(crd) || height == 0 {
+		return ""
+	}
+
+	// Images the chain's version rules allow at the snapshot's height.
+	var images []string
+	if vrs := ChainVersionAt(crd, height); vrs != nil {
+		images = append(images, vrs.Image)
+		// A node halted at the upgrade height still runs the prior version's image.
+		if prev := ChainVersionAt(crd, height-1); prev != nil && height == vrs.UpgradeHeight {
+			images = append(images, prev.Image)
+		}
+	}
+	upgraded := lo.Uniq(append(lo.Keys(crd.Status.Upgrades), lo.Keys(crd.Status.AppliedUpgrades)...))
+	slices.Sort(upgraded)
+	for _, instance := range upgraded {
+		if vrs := instanceChainVersionAt(crd, instance, height); vrs != nil {
+			images = append(images, vrs.Image)
+		}
+	}
+	if len(images) == 0 {
+		if len(crd.Spec.ChainSpec.Versions) == 0 {
+			return ""
+		}
+		return fmt.Sprintf("height %d precedes all chain versions", height)
+	}
+
+	image := snapshot.Annotations[kube.SnapshotImageAnnotation]
+	if image == "" || slices.Contains(images, image) {
+		return ""
+	}
+	return fmt.Sprintf("image %q does not match version image %q at height %d", image, images[0], height)
+}
@@ -4,14 +4,18 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
 	"github.com/strangelove-ventures/cosmos-operator/internal/diff"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"github.com/strangelove-ventures/cosmos-operator/internal/test"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -27,9 +31,11 @@ func TestPVCControl_Reconcile(t *testing.T) {
 	ctx := context.Background()
 	const namespace = "test"
 
-	testPVCControl := func(client Client) PVCControl {
-		control := NewPVCControl(client)
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+	testPVCControl := func(pvcClient Client) PVCControl {
+		control := NewPVCControl(pvcClient, mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			panic("collector should not be called")
+		}})
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, _ ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
 			panic("recentVolumeSnapshot should not be called")
 		}
 		return control
@@ -113,7 +119,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		}
 
 		var volCallCount int
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, _ ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
 			require.NotNil(t, ctx)
 			require.Equal(t, &mClient, lister)
 			require.Equal(t, namespace, namespace)
@@ -164,7 +170,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		}
 		crd.Spec.VolumeClaimTemplate.DataSource = crdDataSource
 
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, _ ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
 			panic("should not be called")
 		}
 
@@ -204,7 +210,7 @@ func TestPVCControl_Reconcile(t *testing.T) {
 			VolumeSnapshotSelector: map[string]string{"label": "vol-snapshot"},
 		}
 		var volCallCount int
-		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, _ ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
 			volCallCount++
 			return nil, errors.New("boom")
 		}
@@ -218,6 +224,178 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		require.Nil(t, mClient.LastCreateObject.Spec.DataSource)
 	})
 
+	t.Run("create - autoDataSource sibling clone", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Namespace = namespace
+		crd.Name = "hub"
+		crd.Spec.Replicas = 2
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{
+			VolumeSnapshotSelector: map[string]string{"label": "vol-snapshot"},
+			SiblingClone:           &cosmosv1.SiblingCloneSpec{MinAvailable: 1},
+		}
+
+		existing := BuildPVCs(&crd, map[int32]*dataSource{}, nil)[0].Object()
+		existing.Status.Phase = corev1.ClaimBound
+		existing.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("250Gi")}
+
+		var mClient mockPVCClient
+		mClient.ObjectList = corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{*existing},
+		}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))}}
+		coll := cosmos.StatusCollection{{Pod: pod}}
+
+		control := testPVCControl(&mClient)
+		control.recentVolumeSnapshot = func(ctx context.Context, lister kube.Lister, namespace string, selector map[string]string, _ ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
+			return nil, errors.New("no snapshots")
+		}
+		control.collector = mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			require.Equal(t, client.ObjectKeyFromObject(&crd), controller)
+			return coll
+		}}
+
+		requeue, rerr := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.True(t, requeue)
+
+		require.Equal(t, 1, mClient.CreateCount)
+		got := mClient.LastCreateObject
+		require.Equal(t, "pvc-hub-1", got.Name)
+		require.Equal(t, &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc-hub-0"}, got.Spec.DataSource)
+		require.Equal(t, resource.MustParse("250Gi"), got.Spec.Resources.Requests[corev1.ResourceStorage])
+		require.Empty(t, crd.Status.SiblingClones)
+
+		t.Run("not enough in-sync pods", func(t *testing.T) {
+			var mClient mockPVCClient
+			mClient.ObjectList = corev1.PersistentVolumeClaimList{
+				Items: []corev1.PersistentVolumeClaim{*existing},
+			}
+			crd := crd.DeepCopy()
+			crd.Spec.VolumeClaimTemplate.AutoDataSource.SiblingClone.MinAvailable = 2
+
+			control.client = &mClient
+			requeue, rerr := control.Reconcile(ctx, nopReporter, crd, &PVCStatusChanges{})
+			require.NoError(t, rerr)
+			require.True(t, requeue)
+
+			require.Equal(t, 1, mClient.CreateCount)
+			require.Nil(t, mClient.LastCreateObject.Spec.DataSource)
+		})
+	})
+
+	t.Run("create - autoDataSource sibling clone with pod deletion", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Namespace = namespace
+		crd.Name = "hub"
+		crd.Spec.Replicas = 3
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{
+			SiblingClone: &cosmosv1.SiblingCloneSpec{DeletePod: true, MinAvailable: 1},
+		}
+
+		existing := BuildPVCs(&crd, map[int32]*dataSource{}, nil)[0].Object()
+		existing.Status.Phase = corev1.ClaimBound
+		existing.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("250Gi")}
+
+		var mClient mockPVCClient
+		mClient.ObjectList = corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{*existing},
+		}
+
+		pod, err := NewPodBuilder(&crd).WithOrdinal(0).Build()
+		require.NoError(t, err)
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour))}}
+		coll := cosmos.StatusCollection{{Pod: pod}}
+
+		control := testPVCControl(&mClient)
+		control.collector = mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			return coll
+		}}
+
+		// Signal pod deletion.
+		requeue, rerr := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.True(t, requeue)
+		require.Zero(t, mClient.CreateCount)
+
+		require.Len(t, crd.Status.SiblingClones, 2)
+		for _, name := range []string{"pvc-hub-1", "pvc-hub-2"} {
+			require.Equal(t, "hub-0", crd.Status.SiblingClones[name].SourcePod, name)
+			require.Equal(t, "pvc-hub-0", crd.Status.SiblingClones[name].SourcePVC, name)
+		}
+		_, isCandidate := podCandidates(&crd)["hub-0"]
+		require.True(t, isCandidate)
+
+		// Still waiting for pod deletion.
+		requeue, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.True(t, requeue)
+		require.Zero(t, mClient.CreateCount)
+
+		// Pod deleted.
+		coll = nil
+		requeue, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.True(t, requeue)
+		require.Equal(t, 2, mClient.CreateCount)
+		for _, pvc := range mClient.CreatedObjects {
+			require.Equal(t, &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc-hub-0"}, pvc.Spec.DataSource)
+		}
+
+		// New PVCs bound; restore pod.
+		var pvcs corev1.PersistentVolumeClaimList
+		pvcs.Items = append(pvcs.Items, *existing)
+		for _, pvc := range mClient.CreatedObjects {
+			pvc.Status.Phase = corev1.ClaimBound
+			pvcs.Items = append(pvcs.Items, *pvc)
+		}
+		mClient.ObjectList = pvcs
+		_, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.Empty(t, crd.Status.SiblingClones)
+	})
+
+	t.Run("create - autoDataSource sibling clone with missing source", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Namespace = namespace
+		crd.Name = "hub"
+		crd.Spec.Replicas = 3
+		crd.Spec.VolumeClaimTemplate.AutoDataSource = &cosmosv1.AutoDataSource{
+			SiblingClone: &cosmosv1.SiblingCloneSpec{DeletePod: true, MinAvailable: 1},
+		}
+		crd.Status.SiblingClones = map[string]cosmosv1.FullNodeSiblingCloneStatus{
+			"pvc-hub-1": {SourcePod: "hub-0", SourcePVC: "pvc-hub-0"},
+			"pvc-hub-2": {SourcePod: "hub-9", SourcePVC: "pvc-hub-9"},
+		}
+
+		existing := BuildPVCs(&crd, map[int32]*dataSource{}, nil)[0].Object()
+		existing.Status.Phase = corev1.ClaimBound
+
+		var mClient mockPVCClient
+		mClient.ObjectList = corev1.PersistentVolumeClaimList{
+			Items: []corev1.PersistentVolumeClaim{*existing},
+		}
+
+		control := testPVCControl(&mClient)
+		control.collector = mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			return nil
+		}}
+
+		requeue, rerr := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.True(t, requeue)
+
+		// The clone in progress continues and the other shares its source.
+		require.Equal(t, 2, mClient.CreateCount)
+		for _, pvc := range mClient.CreatedObjects {
+			require.Equal(t, &corev1.TypedLocalObjectReference{Kind: "PersistentVolumeClaim", Name: "pvc-hub-0"}, pvc.Spec.DataSource)
+		}
+		require.Len(t, crd.Status.SiblingClones, 2)
+		require.Equal(t, "pvc-hub-0", crd.Status.SiblingClones["pvc-hub-2"].SourcePVC)
+	})
+
 	t.Run("updates", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Name = "hub"
@@ -253,6 +431,73 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		require.Equal(t, crd.Spec.VolumeClaimTemplate.Resources, gotPatch.Spec.Resources)
 	})
 
+	t.Run("updates - resize failures", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Name = "hub"
+		crd.Namespace = namespace
+		crd.Spec.Replicas = 2
+		crd.Spec.VolumeClaimTemplate.StorageClassName = "standard"
+
+		pvcs := BuildPVCs(&crd, map[int32]*dataSource{}, nil)
+		existing := []corev1.PersistentVolumeClaim{*pvcs[0].Object(), *pvcs[1].Object()}
+		for i := range existing {
+			existing[i].Status.Phase = corev1.ClaimBound
+		}
+		existing[1].Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+			{Type: pvcControllerResizeError, Status: corev1.ConditionTrue, Message: "quota exceeded"},
+		}
+
+		var mClient mockPVCClient
+		mClient.ObjectList = corev1.PersistentVolumeClaimList{Items: existing}
+		mClient.Object = storagev1.StorageClass{AllowVolumeExpansion: ptr(false)}
+
+		crd.Spec.VolumeClaimTemplate.Resources.Requests[corev1.ResourceStorage] = resource.MustParse("1Ti")
+
+		control := testPVCControl(&mClient)
+		requeue, rerr := control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+		require.False(t, requeue)
+
+		require.Zero(t, mClient.PatchCount)
+		require.Equal(t, "standard", mClient.GetObjectKey.Name)
+
+		cond := meta.FindStatusCondition(crd.Status.Conditions, cosmosv1.ConditionPVCResizeFailed)
+		require.NotNil(t, cond)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, "ExpansionNotAllowed", cond.Reason)
+		require.Equal(t, "pvc-hub-0: storage class standard does not allow volume expansion; pvc-hub-1: storage class standard does not allow volume expansion", cond.Message)
+
+		// Patch failures
+		mClient.Object = storagev1.StorageClass{AllowVolumeExpansion: ptr(true)}
+		mClient.PatchErr = errors.New("boom")
+		_, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+
+		require.Equal(t, 2, mClient.PatchCount)
+		cond = meta.FindStatusCondition(crd.Status.Conditions, cosmosv1.ConditionPVCResizeFailed)
+		require.Equal(t, "PatchFailed", cond.Reason)
+		require.Equal(t, "pvc-hub-0: boom; pvc-hub-1: boom", cond.Message)
+
+		// Resize errors reported by the PVC
+		mClient.PatchErr = nil
+		_, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+
+		cond = meta.FindStatusCondition(crd.Status.Conditions, cosmosv1.ConditionPVCResizeFailed)
+		require.Equal(t, metav1.ConditionTrue, cond.Status)
+		require.Equal(t, "ResizeError", cond.Reason)
+		require.Equal(t, "pvc-hub-1: quota exceeded", cond.Message)
+
+		// Resolved
+		existing[1].Status.Conditions = nil
+		mClient.ObjectList = corev1.PersistentVolumeClaimList{Items: existing}
+		_, rerr = control.Reconcile(ctx, nopReporter, &crd, &PVCStatusChanges{})
+		require.NoError(t, rerr)
+
+		cond = meta.FindStatusCondition(crd.Status.Conditions, cosmosv1.ConditionPVCResizeFailed)
+		require.Equal(t, metav1.ConditionFalse, cond.Status)
+	})
+
 	t.Run("updates with unbound volumes", func(t *testing.T) {
 		crd := defaultCRD()
 		crd.Name = "hub"
@@ -299,3 +544,53 @@ func TestPVCControl_Reconcile(t *testing.T) {
 		require.Zero(t, mClient.DeleteCount)
 	})
 }
+
+func TestIncompatibleSnapshot(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	crd.Spec.ChainSpec.ChainID = "osmosis-1"
+	crd.Spec.ChainSpec.Versions = []cosmosv1.ChainVersion{
+		{UpgradeHeight: 100, Image: "osmosis:v1"},
+		{UpgradeHeight: 200, Image: "osmosis:v2"},
+	}
+
+	for _, tt := range []struct {
+		Name        string
+		Annotations map[string]string
+		WantOK      bool
+	}{
+		{"no annotations", nil, true},
+		{"same chain", map[string]string{kube.SnapshotChainIDAnnotation: "osmosis-1"}, true},
+		{"different chain", map[string]string{kube.SnapshotChainIDAnnotation: "osmo-test-5"}, false},
+		{"height without image", map[string]string{kube.SnapshotHeightAnnotation: "150"}, true},
+		{"matching image", map[string]string{kube.SnapshotHeightAnnotation: "250", kube.SnapshotImageAnnotation: "osmosis:v2"}, true},
+		{"halted at upgrade height", map[string]string{kube.SnapshotHeightAnnotation: "200", kube.SnapshotImageAnnotation: "osmosis:v1"}, true},
+		{"wrong image", map[string]string{kube.SnapshotHeightAnnotation: "250", kube.SnapshotImageAnnotation: "osmosis:v1"}, false},
+		{"height precedes versions", map[string]string{kube.SnapshotHeightAnnotation: "50"}, false},
+	} {
+		var snapshot snapshotv1.VolumeSnapshot
+		snapshot.Annotations = tt.Annotations
+
+		reason := incompatibleSnapshot(&crd, snapshot)
+		if tt.WantOK {
+			require.Empty(t, reason, tt.Name)
+		} else {
+			require.NotEmpty(t, reason, tt.Name)
+		}
+	}
+
+	// Images from upgrades reported by instances apply as they do to pods.
+	crd.Spec.ChainSpec.UpgradeImages = map[string]string{"v3": "osmosis:v3"}
+	crd.Status.Upgrades = map[string]cosmosv1.UpgradeStatus{"osmosis-0": {Name: "v3", Height: 300}}
+	var snapshot snapshotv1.VolumeSnapshot
+	snapshot.Annotations = map[string]string{kube.SnapshotHeightAnnotation: "350", kube.SnapshotImageAnnotation: "osmosis:v3"}
+	require.Empty(t, incompatibleSnapshot(&crd, snapshot))
+	snapshot.Annotations[kube.SnapshotHeightAnnotation] = "250"
+	require.NotEmpty(t, incompatibleSnapshot(&crd, snapshot))
+
+	crd.Spec.ChainSpec.Versions = nil
+	crd.Spec.ChainSpec.UpgradeImages = nil
+	snapshot.Annotations = map[string]string{kube.SnapshotHeightAnnotation: "50", kube.SnapshotImageAnnotation: "other:v1"}
+	require.Empty(t, incompatibleSnapshot(&crd, snapshot))
+}
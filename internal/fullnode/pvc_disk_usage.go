@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 // DiskUsager fetches disk usage statistics
@@ -26,6 +27,17 @@ type PVCDiskUsage struct {
 	Name        string // pvc name
 	PercentUsed int
 	Capacity    resource.Quantity
+	UsedBytes   int64
+	TotalBytes  int64       // filesystem size, may differ slightly from Capacity
+	Growth      *DiskGrowth // set by PVCGrowth if enough history
+	PodName     string
+
+	// True if the PVC's StorageClass does not allow volume expansion.
+	ExpansionUnsupported bool
+	// True while a requested expansion has not completed.
+	Resizing bool
+	// When the PVC started waiting on a filesystem resize by the kubelet. Zero if not waiting.
+	FileSystemResizePendingSince time.Time
 }
 
 type DiskUsageCollector struct {
@@ -50,6 +62,9 @@ func (c DiskUsageCollector) CollectDiskUsage(ctx context.Context, crd *cosmosv1.
 		return nil, fmt.Errorf("list pods: %w", err)
 	}
 
+	// Pods in debug mode are excluded from self-healing.
+	pods.Items = lo.Filter(pods.Items, func(pod corev1.Pod, _ int) bool { return !isDebugPod(&pod) })
+
 	if len(pods.Items) == 0 {
 		return nil, errors.New("no pods found")
 	}
@@ -70,17 +85,15 @@ func (c DiskUsageCollector) CollectDiskUsage(ctx context.Context, crd *cosmosv1.
 				errs[i] = fmt.Errorf("pod %s %s: %w", pod.Name, resp.Dir, err)
 				return nil
 			}
-
-			// Find matching PVC to capture its actual capacity
-			name := PVCName(&pod)
-			key := client.ObjectKey{Namespace: pod.Namespace, Name: name}
-			var pvc corev1.PersistentVolumeClaim
-			if err = c.client.Get(ctx, key, &pvc); err != nil {
-				errs[i] = fmt.Errorf("get pvc %s: %w", key, err)
+			if resp.AllBytes == 0 {
+				errs[i] = fmt.Errorf("pod %s %s: no disk capacity reported", pod.Name, resp.Dir)
+				return nil
 			}
 
-			found[i].Name = name
-			found[i].Capacity = pvc.Status.Capacity[corev1.ResourceStorage]
+			// Find matching PVC to capture its actual capacity
+			found[i], errs[i] = c.PVCUsage(ctx, &pod)
+			found[i].UsedBytes = int64(resp.AllBytes - resp.FreeBytes)
+			found[i].TotalBytes = int64(resp.AllBytes)
 			n := (float64(resp.AllBytes-resp.FreeBytes) / float64(resp.AllBytes)) * 100
 			n = math.Round(n)
 			found[i].PercentUsed = int(n)
@@ -99,3 +112,25 @@ func (c DiskUsageCollector) CollectDiskUsage(ctx context.Context, crd *cosmosv1.
 
 	return lo.Compact(found), nil
 }
+
+// PVCUsage returns the capacity and resize state of the pod's PVC without disk usage.
+// Returns the PVC's name and pod name even if an error occurs.
+func (c DiskUsageCollector) PVCUsage(ctx context.Context, pod *corev1.Pod) (PVCDiskUsage, error) {
+	usage := PVCDiskUsage{Name: PVCName(pod), PodName: pod.Name}
+	key := client.ObjectKey{Namespace: pod.Namespace, Name: usage.Name}
+	var pvc corev1.PersistentVolumeClaim
+	if err := c.client.Get(ctx, key, &pvc); err != nil {
+		return usage, fmt.Errorf("get pvc %s: %w", key, err)
+	}
+	usage.Capacity = pvc.Status.Capacity[corev1.ResourceStorage]
+	usage.Resizing = pvcResizing(&pvc)
+	usage.FileSystemResizePendingSince = fileSystemResizePendingSince(&pvc)
+	allowed, err := expansionAllowed(ctx, c.client, &pvc)
+	if err != nil {
+		// The preflight check must not discard the usage; a failed expansion is reported when requested.
+		log.FromContext(ctx).Error(err, "Failed to check volume expansion", "pvc", usage.Name)
+		return usage, nil
+	}
+	usage.ExpansionUnsupported = !allowed
+	return usage, nil
+}
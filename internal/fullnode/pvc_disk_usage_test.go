@@ -12,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/cosmos-operator/internal/healthcheck"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -23,6 +24,18 @@ func (fn mockDiskUsager) DiskUsage(ctx context.Context, host, homeDir string) (h
 	return fn(ctx, host, homeDir)
 }
 
+// storageClassErrReader fails to get storage classes.
+type storageClassErrReader struct {
+	*mockClient[*corev1.Pod]
+}
+
+func (r storageClassErrReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*storagev1.StorageClass); ok {
+		return errors.New("forbidden")
+	}
+	return r.mockClient.Get(ctx, key, obj, opts...)
+}
+
 func TestCollectDiskUsage(t *testing.T) {
 	t.Parallel()
 
@@ -102,6 +115,11 @@ func TestCollectDiskUsage(t *testing.T) {
 		require.Equal(t, "pvc-cosmoshub-0", result.Name)
 		require.Equal(t, 10, result.PercentUsed)
 		require.Equal(t, resource.MustParse("500Gi"), result.Capacity)
+		require.EqualValues(t, 100, result.UsedBytes)
+		require.EqualValues(t, 1000, result.TotalBytes)
+		require.Equal(t, "cosmoshub-0", result.PodName)
+		require.False(t, result.Resizing)
+		require.False(t, result.ExpansionUnsupported)
 
 		result = got[1]
 		require.Equal(t, "pvc-cosmoshub-1", result.Name)
@@ -198,6 +216,50 @@ func TestCollectDiskUsage(t *testing.T) {
 		require.NotContains(t, gotNames, "pvc-cosmoshub-1")
 	})
 
+	t.Run("storage class error", func(t *testing.T) {
+		var reader mockReader
+		reader.ObjectList = corev1.PodList{Items: validPods}
+		reader.Object = corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptr("premium")},
+		}
+
+		diskClient := mockDiskUsager(func(ctx context.Context, host, homeDir string) (healthcheck.DiskUsageResponse, error) {
+			return healthcheck.DiskUsageResponse{AllBytes: 100, FreeBytes: 50}, nil
+		})
+
+		coll := NewDiskUsageCollector(diskClient, storageClassErrReader{&reader})
+		got, err := coll.CollectDiskUsage(ctx, &crd)
+
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		for _, usage := range got {
+			require.Equal(t, 50, usage.PercentUsed)
+			require.False(t, usage.ExpansionUnsupported)
+		}
+	})
+
+	t.Run("zero disk capacity", func(t *testing.T) {
+		var reader mockReader
+		reader.ObjectList = corev1.PodList{Items: validPods}
+
+		diskClient := mockDiskUsager(func(ctx context.Context, host, homeDir string) (healthcheck.DiskUsageResponse, error) {
+			if host == "http://10.0.0.1" {
+				return healthcheck.DiskUsageResponse{Dir: "/some/dir"}, nil
+			}
+			return healthcheck.DiskUsageResponse{
+				AllBytes:  100,
+				FreeBytes: 100,
+			}, nil
+		})
+
+		coll := NewDiskUsageCollector(diskClient, &reader)
+		got, err := coll.CollectDiskUsage(ctx, &crd)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		require.NotContains(t, lo.Map(got, func(item PVCDiskUsage, _ int) string { return item.Name }), "pvc-cosmoshub-1")
+	})
+
 	t.Run("disk client error", func(t *testing.T) {
 		var reader mockReader
 		reader.ObjectList = corev1.PodList{Items: []corev1.Pod{
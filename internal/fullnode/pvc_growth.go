@@ -0,0 +1,171 @@
+package fullnode
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	pvcGrowthWindow     = 6 * time.Hour
+	pvcGrowthMinSpan    = 30 * time.Minute
+	pvcGrowthMaxSamples = 360
+
+	pvcProjectionRefresh = 10 * time.Minute
+
+	defaultPredictiveHorizon    = 24 * time.Hour
+	defaultPredictiveTargetDays = 7
+)
+
+// DiskGrowth is the growth of a PVC's used bytes.
+type DiskGrowth struct {
+	BytesPerSecond float64
+	// Projected time until the filesystem is full. Zero if usage is not growing.
+	TimeToFull time.Duration
+}
+
+// PVCGrowth keeps a history of disk usage per PVC to compute growth rates.
+// Samples older than a few hours are discarded. Safe for concurrent use.
+type PVCGrowth struct {
+	mu      sync.Mutex
+	history map[client.ObjectKey]*diskHistory
+	now     func() time.Time
+}
+
+type diskHistory struct {
+	samples  []diskSample
+	lastSeen time.Time
+}
+
+type diskSample struct {
+	ts   time.Time
+	used int64
+}
+
+func NewPVCGrowth() *PVCGrowth {
+	return &PVCGrowth{
+		history: make(map[client.ObjectKey]*diskHistory),
+		now:     time.Now,
+	}
+}
+
+// Observe records a sample for each PVC and sets Growth on usage with at least 30 minutes of history.
+func (g *PVCGrowth) Observe(crd *cosmosv1.CosmosFullNode, usage []PVCDiskUsage) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for key, h := range g.history {
+		if now.Sub(h.lastSeen) > pvcGrowthWindow {
+			delete(g.history, key)
+		}
+	}
+
+	for i := range usage {
+		key := client.ObjectKey{Namespace: crd.Namespace, Name: usage[i].Name}
+		h := g.history[key]
+		if h == nil {
+			h = new(diskHistory)
+			g.history[key] = h
+		}
+		h.lastSeen = now
+		h.samples = append(h.samples, diskSample{ts: now, used: usage[i].UsedBytes})
+		for len(h.samples) > 0 && (now.Sub(h.samples[0].ts) > pvcGrowthWindow || len(h.samples) > pvcGrowthMaxSamples) {
+			h.samples = h.samples[1:]
+		}
+
+		if now.Sub(h.samples[0].ts) < pvcGrowthMinSpan {
+			continue
+		}
+		growth := DiskGrowth{BytesPerSecond: h.slope()}
+		if free := usage[i].TotalBytes - usage[i].UsedBytes; growth.BytesPerSecond > 0 && free > 0 {
+			growth.TimeToFull = time.Duration(float64(free) / growth.BytesPerSecond * float64(time.Second))
+		}
+		usage[i].Growth = &growth
+	}
+}
+
+// slope returns the least squares rate of change of used bytes per second.
+func (h *diskHistory) slope() float64 {
+	var (
+		n      = float64(len(h.samples))
+		start  = h.samples[0].ts
+		sumX   float64
+		sumY   float64
+		sumXY  float64
+		sumXX  float64
+		offset = float64(h.samples[0].used)
+	)
+	for _, s := range h.samples {
+		x := s.ts.Sub(start).Seconds()
+		// Offset to preserve float precision for large byte counts.
+		y := float64(s.used) - offset
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// predictiveResize returns true if the PVC's projected time until full is less than the horizon.
+func predictiveResize(spec *cosmosv1.PVCPredictiveScaleSpec, pvc PVCDiskUsage) bool {
+	if spec == nil || pvc.Growth == nil || pvc.Growth.TimeToFull == 0 {
+		return false
+	}
+	return pvc.Growth.TimeToFull < durationOrDefault(spec.Horizon, defaultPredictiveHorizon)
+}
+
+// predictiveCapacity returns the capacity needed for projected usage after the target days to stay below the
+// used space percentage, rounded up to the next GiB.
+func predictiveCapacity(spec *cosmosv1.PVCAutoScaleSpec, pvc PVCDiskUsage) resource.Quantity {
+	days := int32(defaultPredictiveTargetDays)
+	if spec.Predictive.TargetDays != nil {
+		days = *spec.Predictive.TargetDays
+	}
+	projected := float64(pvc.UsedBytes) + pvc.Growth.BytesPerSecond*float64(days)*24*60*60
+	needed := projected * 100 / float64(max(spec.UsedSpacePercentage, 1))
+	const gib = 1 << 30
+	return *resource.NewQuantity(int64(math.Ceil(needed/gib))*gib, resource.BinarySI)
+}
+
+// PVCProjections returns the projection status for PVCs with growth.
+// Returns false if prev is recent and covers the same PVCs, in which case the status should not be updated.
+func PVCProjections(prev map[string]*cosmosv1.PVCProjectionStatus, usage []PVCDiskUsage, now time.Time) (map[string]*cosmosv1.PVCProjectionStatus, bool) {
+	next := make(map[string]*cosmosv1.PVCProjectionStatus)
+	for _, pvc := range usage {
+		if pvc.Growth == nil {
+			continue
+		}
+		stat := &cosmosv1.PVCProjectionStatus{
+			UsedBytes:    pvc.UsedBytes,
+			GrowthPerDay: *resource.NewQuantity(int64(pvc.Growth.BytesPerSecond*24*60*60), resource.BinarySI),
+			ProjectedAt:  metav1.NewTime(now),
+		}
+		if pvc.Growth.TimeToFull > 0 {
+			stat.TimeToFull = &metav1.Duration{Duration: pvc.Growth.TimeToFull.Round(time.Minute)}
+		}
+		next[pvc.Name] = stat
+	}
+
+	changed := len(next) != len(prev)
+	for name := range next {
+		p, ok := prev[name]
+		if !ok || now.Sub(p.ProjectedAt.Time) >= pvcProjectionRefresh {
+			changed = true
+		}
+	}
+	if len(next) == 0 {
+		next = nil
+	}
+	return next, changed
+}
@@ -0,0 +1,105 @@
+package fullnode
+
+import (
+	"testing"
+	"time"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPVCGrowth_Observe(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	now := time.Now()
+	growth := NewPVCGrowth()
+	growth.now = func() time.Time { return now }
+
+	const total = 1000 << 30
+	observe := func(used int64) []PVCDiskUsage {
+		usage := []PVCDiskUsage{
+			{Name: "pvc-osmosis-0", UsedBytes: used, TotalBytes: total},
+			{Name: "pvc-osmosis-1", UsedBytes: 500 << 30, TotalBytes: total},
+		}
+		growth.Observe(&crd, usage)
+		return usage
+	}
+
+	// 1MiB per second
+	const rate = 1 << 20
+	used := int64(100 << 30)
+	for i := 0; i < 30; i++ {
+		got := observe(used)
+		require.Nil(t, got[0].Growth, i)
+		now = now.Add(time.Minute)
+		used += 60 * rate
+	}
+
+	got := observe(used)
+	require.NotNil(t, got[0].Growth)
+	require.InDelta(t, rate, got[0].Growth.BytesPerSecond, 1)
+	require.InDelta(t, (total-used)/rate, got[0].Growth.TimeToFull.Seconds(), 1)
+
+	require.NotNil(t, got[1].Growth)
+	require.Zero(t, got[1].Growth.BytesPerSecond)
+	require.Zero(t, got[1].Growth.TimeToFull)
+
+	// Old samples are discarded.
+	now = now.Add(7 * time.Hour)
+	got = observe(used)
+	require.Nil(t, got[0].Growth)
+
+	// Other CosmosFullNodes have separate history.
+	other := defaultCRD()
+	other.Namespace = "other"
+	usage := []PVCDiskUsage{{Name: "pvc-osmosis-0", UsedBytes: used, TotalBytes: total}}
+	growth.Observe(&other, usage)
+	require.Nil(t, usage[0].Growth)
+}
+
+func TestPVCProjections(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	usage := []PVCDiskUsage{
+		{Name: "pvc-0", UsedBytes: 100, Growth: &DiskGrowth{BytesPerSecond: 1 << 20, TimeToFull: 90*time.Minute + 10*time.Second}},
+		{Name: "pvc-1", UsedBytes: 200, Growth: &DiskGrowth{}},
+		{Name: "pvc-2", UsedBytes: 300},
+	}
+
+	got, changed := PVCProjections(nil, usage, now)
+	require.True(t, changed)
+	want := map[string]*cosmosv1.PVCProjectionStatus{
+		"pvc-0": {
+			UsedBytes:    100,
+			GrowthPerDay: *resource.NewQuantity(86400<<20, resource.BinarySI),
+			TimeToFull:   &metav1.Duration{Duration: 90 * time.Minute},
+			ProjectedAt:  metav1.NewTime(now),
+		},
+		"pvc-1": {
+			UsedBytes:    200,
+			GrowthPerDay: *resource.NewQuantity(0, resource.BinarySI),
+			ProjectedAt:  metav1.NewTime(now),
+		},
+	}
+	require.Equal(t, want, got)
+
+	_, changed = PVCProjections(got, usage, now.Add(9*time.Minute))
+	require.False(t, changed)
+
+	_, changed = PVCProjections(got, usage, now.Add(10*time.Minute))
+	require.True(t, changed)
+
+	_, changed = PVCProjections(got, usage[:1], now)
+	require.True(t, changed)
+
+	got, changed = PVCProjections(got, nil, now)
+	require.True(t, changed)
+	require.Nil(t, got)
+
+	_, changed = PVCProjections(nil, nil, now)
+	require.False(t, changed)
+}
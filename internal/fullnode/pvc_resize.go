@@ -0,0 +1,137 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PVC conditions set by the external resizer and kubelet if expansion fails.
+const (
+	pvcControllerResizeError corev1.PersistentVolumeClaimConditionType = "ControllerResizeError"
+	pvcNodeResizeError       corev1.PersistentVolumeClaimConditionType = "NodeResizeError"
+)
+
+// expansionAllowed returns false if the PVC's StorageClass does not allow volume expansion or does not exist.
+// PVCs without a StorageClass are assumed to allow expansion.
+func expansionAllowed(ctx context.Context, getter Getter, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	name := lo.FromPtr(pvc.Spec.StorageClassName)
+	if name == "" {
+		return true, nil
+	}
+	var sc storagev1.StorageClass
+	if err := getter.Get(ctx, client.ObjectKey{Name: name}, &sc); err != nil {
+		if kube.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("get storage class %s: %w", name, err)
+	}
+	return lo.FromPtr(sc.AllowVolumeExpansion), nil
+}
+
+// pvcResizing returns true while a requested expansion of the PVC has not completed.
+func pvcResizing(pvc *corev1.PersistentVolumeClaim) bool {
+	var (
+		requested = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		capacity  = pvc.Status.Capacity[corev1.ResourceStorage]
+	)
+	if !capacity.IsZero() && requested.Cmp(capacity) > 0 {
+		return true
+	}
+	return pvcCondition(pvc, corev1.PersistentVolumeClaimResizing) != nil ||
+		pvcCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending) != nil
+}
+
+// fileSystemResizePendingSince returns when the PVC started waiting on a filesystem resize by the kubelet.
+// Returns the zero time if not waiting.
+func fileSystemResizePendingSince(pvc *corev1.PersistentVolumeClaim) time.Time {
+	if cond := pvcCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending); cond != nil {
+		return cond.LastTransitionTime.Time
+	}
+	return time.Time{}
+}
+
+// pvcResizeError returns the message of the PVC's resize error condition, if any.
+func pvcResizeError(pvc *corev1.PersistentVolumeClaim) string {
+	for _, typ := range []corev1.PersistentVolumeClaimConditionType{pvcControllerResizeError, pvcNodeResizeError} {
+		if cond := pvcCondition(pvc, typ); cond != nil {
+			return lo.Ternary(cond.Message != "", cond.Message, string(typ))
+		}
+	}
+	return ""
+}
+
+func pvcCondition(pvc *corev1.PersistentVolumeClaim, typ corev1.PersistentVolumeClaimConditionType) *corev1.PersistentVolumeClaimCondition {
+	for i := range pvc.Status.Conditions {
+		cond := &pvc.Status.Conditions[i]
+		if cond.Type == typ && cond.Status == corev1.ConditionTrue {
+			return cond
+		}
+	}
+	return nil
+}
+
+// pvcFailure is the reason a PVC cannot be resized.
+type pvcFailure struct {
+	Reason  string
+	Message string
+}
+
+// pvcFailureCondition returns the condition of conditionType for the failures keyed by PVC name.
+// The reason is that of the first PVC by name.
+func pvcFailureCondition(conditionType string, generation int64, failures map[string]pvcFailure) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "NoFailures",
+		Message:            "All PVCs can be resized",
+	}
+	if len(failures) == 0 {
+		return cond
+	}
+	names := lo.Keys(failures)
+	slices.Sort(names)
+	msgs := lo.Map(names, func(name string, _ int) string {
+		return fmt.Sprintf("%s: %s", name, failures[name].Message)
+	})
+	cond.Status = metav1.ConditionTrue
+	cond.Reason = failures[names[0]].Reason
+	cond.Message = strings.Join(msgs, "; ")
+	return cond
+}
+
+// setCondition sets cond on conditions. A false condition is only set if the condition exists, so that conditions
+// only appear once relevant. Returns true if the condition became true.
+func setCondition(conditions *[]metav1.Condition, cond metav1.Condition) bool {
+	prev := meta.FindStatusCondition(*conditions, cond.Type)
+	if prev == nil && cond.Status != metav1.ConditionTrue {
+		return false
+	}
+	becameTrue := cond.Status == metav1.ConditionTrue && (prev == nil || prev.Status != metav1.ConditionTrue)
+	meta.SetStatusCondition(conditions, cond)
+	return becameTrue
+}
+
+// PVCAutoScaleCondition returns the PVCAutoScaleBlocked condition for the PVCs that need expansion but cannot be
+// expanded. Assumes crd.Spec.SelfHeal.PVCAutoScale is set.
+func PVCAutoScaleCondition(crd *cosmosv1.CosmosFullNode, usage []PVCDiskUsage, now time.Time) metav1.Condition {
+	failures := make(map[string]pvcFailure)
+	for _, pvc := range usage {
+		if f := pvcAutoScaleBlocked(crd, pvc, now); f != nil {
+			failures[pvc.Name] = *f
+		}
+	}
+	return pvcFailureCondition(cosmosv1.ConditionPVCAutoScaleBlocked, crd.Generation, failures)
+}
@@ -0,0 +1,132 @@
+package fullnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExpansionAllowed(t *testing.T) {
+	t.Parallel()
+
+	type mockGetter = mockClient[*storagev1.StorageClass]
+
+	ctx := context.Background()
+	pvc := &corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{StorageClassName: ptr("premium-rwo")}}
+
+	for _, tt := range []struct {
+		Allow *bool
+		Want  bool
+	}{
+		{ptr(true), true},
+		{ptr(false), false},
+		{nil, false},
+	} {
+		var getter mockGetter
+		getter.Object = storagev1.StorageClass{AllowVolumeExpansion: tt.Allow}
+
+		got, err := expansionAllowed(ctx, &getter, pvc)
+		require.NoError(t, err)
+		require.Equal(t, tt.Want, got)
+		require.Equal(t, "premium-rwo", getter.GetObjectKey.Name)
+		require.Empty(t, getter.GetObjectKey.Namespace)
+	}
+
+	t.Run("no storage class", func(t *testing.T) {
+		got, err := expansionAllowed(ctx, nil, &corev1.PersistentVolumeClaim{})
+		require.NoError(t, err)
+		require.True(t, got)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		var getter mockGetter
+		getter.GetObjectErr = kerrors.NewNotFound(schema.GroupResource{Group: "storage.k8s.io", Resource: "storageclasses"}, "premium-rwo")
+
+		got, err := expansionAllowed(ctx, &getter, pvc)
+		require.NoError(t, err)
+		require.False(t, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var getter mockGetter
+		getter.GetObjectErr = errors.New("boom")
+
+		_, err := expansionAllowed(ctx, &getter, pvc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "boom")
+	})
+}
+
+func TestPVCResizeState(t *testing.T) {
+	t.Parallel()
+
+	pending := metav1.NewTime(time.Now().Add(-time.Minute))
+	pvc := func(requested string, conds ...corev1.PersistentVolumeClaimCondition) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)},
+			}},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity:   corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("100Gi")},
+				Conditions: conds,
+			},
+		}
+	}
+
+	require.False(t, pvcResizing(pvc("100Gi")))
+	require.False(t, pvcResizing(pvc("100Gi", corev1.PersistentVolumeClaimCondition{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionFalse})))
+	require.True(t, pvcResizing(pvc("110Gi")))
+	require.True(t, pvcResizing(pvc("100Gi", corev1.PersistentVolumeClaimCondition{Type: corev1.PersistentVolumeClaimResizing, Status: corev1.ConditionTrue})))
+
+	resizePending := pvc("100Gi", corev1.PersistentVolumeClaimCondition{
+		Type:               corev1.PersistentVolumeClaimFileSystemResizePending,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: pending,
+	})
+	require.True(t, pvcResizing(resizePending))
+	require.Equal(t, pending.Time, fileSystemResizePendingSince(resizePending))
+	require.Zero(t, fileSystemResizePendingSince(pvc("110Gi")))
+
+	require.Empty(t, pvcResizeError(resizePending))
+	require.Equal(t, "quota exceeded", pvcResizeError(pvc("110Gi", corev1.PersistentVolumeClaimCondition{
+		Type: pvcControllerResizeError, Status: corev1.ConditionTrue, Message: "quota exceeded",
+	})))
+	require.Equal(t, "NodeResizeError", pvcResizeError(pvc("110Gi", corev1.PersistentVolumeClaimCondition{
+		Type: pvcNodeResizeError, Status: corev1.ConditionTrue,
+	})))
+}
+
+func TestSetCondition(t *testing.T) {
+	t.Parallel()
+
+	var conds []metav1.Condition
+	ok := pvcFailureCondition("Test", 1, nil)
+	require.False(t, setCondition(&conds, ok))
+	require.Empty(t, conds)
+
+	failed := pvcFailureCondition("Test", 1, map[string]pvcFailure{
+		"pvc-b": {Reason: "PatchFailed", Message: "boom"},
+		"pvc-a": {Reason: "ResizeError", Message: "quota"},
+	})
+	require.Equal(t, metav1.ConditionTrue, failed.Status)
+	require.Equal(t, "ResizeError", failed.Reason)
+	require.Equal(t, "pvc-a: quota; pvc-b: boom", failed.Message)
+
+	require.True(t, setCondition(&conds, failed))
+	require.False(t, setCondition(&conds, failed))
+	require.Len(t, conds, 1)
+
+	require.False(t, setCondition(&conds, ok))
+	require.Len(t, conds, 1)
+	require.Equal(t, metav1.ConditionFalse, conds[0].Status)
+	require.Equal(t, "NoFailures", conds[0].Reason)
+}
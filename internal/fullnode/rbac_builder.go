@@ -20,6 +20,15 @@ func roleName(crd *cosmosv1.CosmosFullNode) string {
 	return crd.Name + "-vc-r"
 }
 
+func instanceNames(crd *cosmosv1.CosmosFullNode) []string {
+	start := crd.Spec.Ordinals.Start
+	names := make([]string, 0, crd.Spec.Replicas)
+	for i := start; i < start+crd.Spec.Replicas; i++ {
+		names = append(names, instanceName(crd, i))
+	}
+	return names
+}
+
 func roleBindingName(crd *cosmosv1.CosmosFullNode) string {
 	return crd.Name + "-vc-rb"
 }
@@ -76,14 +85,20 @@ func BuildRoles(crd *cosmosv1.CosmosFullNode) []diff.Resource[*rbacv1.Role] {
 				Resources: []string{"cosmosfullnodes"},
 				Verbs:     []string{"get"},
 			},
-			{
-				APIGroups: []string{"cosmos.strange.love"},
-				Resources: []string{"cosmosfullnodes/status"},
-				Verbs:     []string{"patch"},
-			},
 		},
 	}
 
+	// The version check reports on its own pod's annotations.
+	// Limited to the crd's instances; a rule without resource names would allow patching any pod.
+	if names := instanceNames(crd); len(names) > 0 {
+		cr.Rules = append(cr.Rules, rbacv1.PolicyRule{
+			APIGroups:     []string{""},
+			Resources:     []string{"pods"},
+			ResourceNames: names,
+			Verbs:         []string{"patch"},
+		})
+	}
+
 	cr.Labels = defaultLabels(crd, kube.ComponentLabel, "vc")
 
 	diffCr[0] = diff.Adapt(&cr, 0)
@@ -3,6 +3,7 @@ package fullnode
 import (
 	"testing"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
 
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -69,12 +70,20 @@ func TestBuildRBAC(t *testing.T) {
 				Verbs:     []string{"get"},
 			},
 			{
-				APIGroups: []string{"cosmos.strange.love"},
-				Resources: []string{"cosmosfullnodes/status"},
-				Verbs:     []string{"patch"},
+				APIGroups:     []string{""},
+				Resources:     []string{"pods"},
+				ResourceNames: []string{"hub-0", "hub-1", "hub-2"},
+				Verbs:         []string{"patch"},
 			},
 		}, role.Rules)
 
+		// Without instances, pods must not be patchable.
+		crd.Spec.Replicas = 0
+		role = BuildRoles(&crd)[0].Object()
+		require.False(t, lo.ContainsBy(role.Rules, func(rule rbacv1.PolicyRule) bool {
+			return lo.Contains(rule.Verbs, "patch")
+		}))
+
 		rbs := BuildRoleBindings(&crd)
 
 		require.Len(t, rbs, 1) // 1 role in the namespace
@@ -0,0 +1,110 @@
+package fullnode
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/diff"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RenderSnapshot contains existing resources read from a cluster. All fields are optional.
+type RenderSnapshot struct {
+	// ConfigMaps supply existing node keys. Instances without a node key get a stub key derived from the instance name.
+	ConfigMaps []corev1.ConfigMap
+	// Services supply load balancer addresses for external peers.
+	Services []corev1.Service
+	// PVCs supply current sizes so rendered PVCs never shrink.
+	PVCs []corev1.PersistentVolumeClaim
+}
+
+// Render builds the resources the controller would create for crd without contacting a cluster.
+// Resources are labeled and annotated as they would be on creation, so pod revisions can be compared
+// between renders to see which pods a spec change restarts.
+//
+// Objects are returned in the order ServiceAccount, Role, RoleBinding, ConfigMap, Service, PVC, Pod.
+func Render(ctx context.Context, crd *cosmosv1.CosmosFullNode, snapshot RenderSnapshot) ([]client.Object, error) {
+	nodeKeys, kerr := collectNodeKeys(crd, ptrSlice(snapshot.ConfigMaps), stubNodeKey(crd))
+	if kerr != nil {
+		return nil, kerr
+	}
+
+	services := BuildServices(crd)
+	getter := make(renderGetter)
+	for _, svc := range services {
+		getter.add(svc.Object())
+	}
+	for i := range snapshot.Services {
+		getter.add(&snapshot.Services[i])
+	}
+	peers, kerr := NewPeerCollector(getter).Collect(ctx, crd, nodeKeys)
+	if kerr != nil {
+		return nil, kerr
+	}
+
+	cms, err := BuildConfigMaps(crd, peers, nodeKeys)
+	if err != nil {
+		return nil, err
+	}
+	cksums := make(ConfigChecksums)
+	for _, cm := range cms {
+		cksums[client.ObjectKeyFromObject(cm.Object())] = cm.Revision()
+	}
+
+	pods, err := BuildPods(crd, cksums)
+	if err != nil {
+		return nil, err
+	}
+
+	var objs []client.Object
+	objs = append(objs, renderObjects(BuildServiceAccounts(crd))...)
+	objs = append(objs, renderObjects(BuildRoles(crd))...)
+	objs = append(objs, renderObjects(BuildRoleBindings(crd))...)
+	objs = append(objs, renderObjects(cms)...)
+	objs = append(objs, renderObjects(services)...)
+	objs = append(objs, renderObjects(BuildPVCs(crd, nil, ptrSlice(snapshot.PVCs)))...)
+	objs = append(objs, renderObjects(pods)...)
+	return objs, nil
+}
+
+func renderObjects[T client.Object](resources []diff.Resource[T]) []client.Object {
+	return lo.Map(diff.New(nil, resources).Creates(), func(obj T, _ int) client.Object { return obj })
+}
+
+// stubNodeKey derives a node key from the instance name so repeated renders are stable.
+func stubNodeKey(crd *cosmosv1.CosmosFullNode) func(ordinal int32) (*NodeKey, error) {
+	return func(ordinal int32) (*NodeKey, error) {
+		seed := sha256.Sum256([]byte(crd.Namespace + "/" + instanceName(crd, ordinal)))
+		return &NodeKey{
+			PrivKey: NodeKeyPrivKey{
+				Type:  "tendermint/PrivKeyEd25519",
+				Value: ed25519.NewKeyFromSeed(seed[:]),
+			},
+		}, nil
+	}
+}
+
+// renderGetter is an in-memory Getter for services.
+type renderGetter map[client.ObjectKey]*corev1.Service
+
+func (g renderGetter) add(svc *corev1.Service) { g[client.ObjectKeyFromObject(svc)] = svc }
+
+func (g renderGetter) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	svc, ok := obj.(*corev1.Service)
+	if !ok {
+		return fmt.Errorf("unsupported type %T", obj)
+	}
+	found, ok := g[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "services"}, key.Name)
+	}
+	*svc = *found.DeepCopy()
+	return nil
+}
@@ -0,0 +1,98 @@
+package fullnode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("happy path", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.Replicas = 2
+
+		objs, err := Render(ctx, &crd, RenderSnapshot{})
+		require.NoError(t, err)
+
+		kinds := lo.Map(objs, func(obj client.Object, _ int) string {
+			switch obj.(type) {
+			case *corev1.ServiceAccount:
+				return "ServiceAccount"
+			case *rbacv1.Role:
+				return "Role"
+			case *rbacv1.RoleBinding:
+				return "RoleBinding"
+			case *corev1.ConfigMap:
+				return "ConfigMap"
+			case *corev1.Service:
+				return "Service"
+			case *corev1.PersistentVolumeClaim:
+				return "PVC"
+			case *corev1.Pod:
+				return "Pod"
+			}
+			return "unknown"
+		})
+		require.Equal(t, []string{
+			"ServiceAccount", "Role", "RoleBinding",
+			"ConfigMap", "ConfigMap",
+			"Service", "Service", "Service",
+			"PVC", "PVC",
+			"Pod", "Pod",
+		}, kinds)
+
+		for _, obj := range objs {
+			require.NotEmpty(t, obj.GetLabels()["app.kubernetes.io/revision"], obj.GetName())
+		}
+
+		again, err := Render(ctx, &crd, RenderSnapshot{})
+		require.NoError(t, err)
+		require.Equal(t, objs, again)
+	})
+
+	t.Run("config change updates pod revision", func(t *testing.T) {
+		crd := defaultCRD()
+		crd.Spec.Replicas = 1
+
+		objs, err := Render(ctx, &crd, RenderSnapshot{})
+		require.NoError(t, err)
+
+		crd.Spec.ChainSpec.Comet.Seeds = "new-seed@1.1.1.1:26656"
+		changed, err := Render(ctx, &crd, RenderSnapshot{})
+		require.NoError(t, err)
+
+		revision := func(objs []client.Object) string {
+			pod, _ := lo.Find(objs, func(obj client.Object) bool { _, ok := obj.(*corev1.Pod); return ok })
+			return pod.GetLabels()["app.kubernetes.io/revision"]
+		}
+		require.NotEqual(t, revision(objs), revision(changed))
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		const nodeKey = `{"priv_key":{"type":"tendermint/PrivKeyEd25519","value":"HBX8VFQ4OdWfOwIOR7jj0af8mVHik5iGW9o1xnn4vRltk1HmwQS2LLGrMPVS2LIUO9BUqmZ1Pjt+qM8x0ibHxQ=="}}`
+
+		crd := defaultCRD()
+		crd.Spec.Replicas = 1
+
+		var cm corev1.ConfigMap
+		cm.Name = "osmosis-0"
+		cm.Namespace = "test"
+		cm.Data = map[string]string{nodeKeyFile: nodeKey}
+
+		objs, err := Render(ctx, &crd, RenderSnapshot{ConfigMaps: []corev1.ConfigMap{cm}})
+		require.NoError(t, err)
+
+		got, ok := lo.Find(objs, func(obj client.Object) bool { _, ok := obj.(*corev1.ConfigMap); return ok })
+		require.True(t, ok)
+		require.Equal(t, nodeKey, got.(*corev1.ConfigMap).Data[nodeKeyFile])
+	})
+}
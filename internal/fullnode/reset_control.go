@@ -0,0 +1,192 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResetControl wipes and re-bootstraps instances requested via the cosmos.strange.love/reset annotation.
+//
+// A reset is tracked in the crd's status. While tracked, BuildPods omits the instance's pod so PodControl deletes it.
+// Once the pod is gone, ResetControl deletes the PVC. PVCControl then recreates the PVC from the configured
+// data source. Once the new PVC exists, the reset is complete and the pod is recreated.
+type ResetControl struct {
+	client         Client
+	collector      StatusCollector
+	statusClient   StatusSyncer
+	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int
+	now            func() time.Time
+
+	// waiting holds the reason each crd's reset request is waiting, so the event is recorded once per reason.
+	waiting *sync.Map
+}
+
+// NewResetControl returns a valid ResetControl.
+func NewResetControl(client Client, collector StatusCollector, statusClient StatusSyncer) ResetControl {
+	return ResetControl{
+		client:         client,
+		collector:      collector,
+		statusClient:   statusClient,
+		computeRollout: kube.ComputeRollout,
+		now:            time.Now,
+		waiting:        new(sync.Map),
+	}
+}
+
+// Reconcile accepts any new reset request and progresses resets in crd's status.
+// The bool return value, if true, indicates the controller should requeue the request.
+func (control ResetControl) Reconcile(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode) (bool, kube.ReconcileError) {
+	var (
+		key  = client.ObjectKeyFromObject(crd)
+		coll = control.collector.Collect(ctx, key)
+	)
+
+	if err := control.acceptRequest(ctx, reporter, crd, coll.SyncedPods()); err != nil {
+		return true, err
+	}
+
+	if len(crd.Status.Resets) == 0 {
+		return false, nil
+	}
+
+	existing := lo.SliceToMap(coll.Pods(), func(pod *corev1.Pod) (string, bool) { return pod.Name, true })
+	wanted := lo.SliceToMap(lo.Range(int(crd.Spec.Replicas)), func(i int) (string, bool) {
+		return instanceName(crd, crd.Spec.Ordinals.Start+int32(i)), true
+	})
+
+	var requeue bool
+	for name, reset := range crd.Status.Resets {
+		if !wanted[name] {
+			delete(crd.Status.Resets, name)
+			continue
+		}
+
+		// Wait for PodControl to delete the pod.
+		if existing[name] {
+			requeue = true
+			continue
+		}
+
+		var pvc corev1.PersistentVolumeClaim
+		err := control.client.Get(ctx, client.ObjectKey{Namespace: crd.Namespace, Name: reset.PVCName}, &pvc)
+		switch {
+		case kube.IsNotFound(err):
+			// Wait for PVCControl to recreate the PVC.
+			requeue = true
+		case err != nil:
+			return true, kube.TransientError(fmt.Errorf("get pvc %s: %w", reset.PVCName, err))
+		case pvc.UID == reset.PVCUID:
+			requeue = true
+			if pvc.DeletionTimestamp != nil {
+				continue
+			}
+			reporter.Info("Deleting pvc for reset", "pvc", pvc.Name)
+			if err := control.client.Delete(ctx, &pvc); kube.IgnoreNotFound(err) != nil {
+				return true, kube.TransientError(fmt.Errorf("delete pvc %s: %w", pvc.Name, err))
+			}
+		default:
+			delete(crd.Status.Resets, name)
+			msg := fmt.Sprintf("Reset of %s complete; recreated pvc %s", name, pvc.Name)
+			reporter.Info(msg)
+			reporter.RecordInfo("ResetComplete", msg)
+		}
+	}
+
+	return requeue, nil
+}
+
+// acceptRequest validates the reset annotation and, if safe, records the reset in crd's status and removes the
+// annotation. If resetting would exceed MaxUnavailable or leave no other in-sync replica, the annotation is left
+// in place and retried on a later reconcile.
+//
+// The reset is persisted in status before the annotation is removed, so a request is never lost once accepted.
+func (control ResetControl) acceptRequest(ctx context.Context, reporter kube.Reporter, crd *cosmosv1.CosmosFullNode, synced []*corev1.Pod) kube.ReconcileError {
+	key := client.ObjectKeyFromObject(crd)
+	val, ok := crd.Annotations[cosmosv1.ResetAnnotation]
+	if !ok {
+		control.waiting.Delete(key)
+		return nil
+	}
+
+	ordinal, err := strconv.ParseInt(val, 10, 32)
+	if err != nil || int32(ordinal) < crd.Spec.Ordinals.Start || int32(ordinal) >= crd.Spec.Ordinals.Start+crd.Spec.Replicas {
+		reporter.RecordError("ResetInvalid", fmt.Errorf("%s=%q is not a valid instance ordinal", cosmosv1.ResetAnnotation, val))
+		control.waiting.Delete(key)
+		return control.clearRequest(ctx, crd)
+	}
+
+	name := instanceName(crd, int32(ordinal))
+	if _, ok := crd.Status.Resets[name]; ok {
+		control.waiting.Delete(key)
+		return control.clearRequest(ctx, crd)
+	}
+
+	available := kube.AvailablePods(synced, 5*time.Second, control.now())
+	if reason := disruptionBlocked(crd, available, name, control.computeRollout); reason != "" {
+		control.recordWaiting(reporter, key, fmt.Sprintf("Waiting to reset %s; %s", name, reason))
+		return nil
+	}
+	control.waiting.Delete(key)
+
+	reset := cosmosv1.FullNodeResetStatus{
+		PVCName:   pvcName(crd, int32(ordinal)),
+		StartedAt: metav1.NewTime(control.now()),
+	}
+	var pvc corev1.PersistentVolumeClaim
+	err = control.client.Get(ctx, client.ObjectKey{Namespace: crd.Namespace, Name: reset.PVCName}, &pvc)
+	switch {
+	case kube.IsNotFound(err):
+	case err != nil:
+		return kube.TransientError(fmt.Errorf("get pvc %s: %w", reset.PVCName, err))
+	default:
+		reset.PVCUID = pvc.UID
+	}
+
+	setReset := func(status *cosmosv1.FullNodeStatus) {
+		if status.Resets == nil {
+			status.Resets = make(map[string]cosmosv1.FullNodeResetStatus)
+		}
+		status.Resets[name] = reset
+	}
+	if err = control.statusClient.SyncUpdate(ctx, key, setReset); err != nil {
+		return kube.TransientError(fmt.Errorf("record reset in status: %w", err))
+	}
+	setReset(&crd.Status)
+
+	msg := fmt.Sprintf("Resetting %s; deleting pod and pvc %s", name, reset.PVCName)
+	reporter.Info(msg)
+	reporter.RecordInfo("Reset", msg)
+
+	return control.clearRequest(ctx, crd)
+}
+
+// recordWaiting records the ResetWaiting event when the request starts waiting or the reason changes.
+func (control ResetControl) recordWaiting(reporter kube.Reporter, key client.ObjectKey, msg string) {
+	if prev, ok := control.waiting.Swap(key, msg); ok && prev == msg {
+		return
+	}
+	reporter.Info(msg)
+	reporter.RecordInfo("ResetWaiting", msg)
+}
+
+func (control ResetControl) clearRequest(ctx context.Context, crd *cosmosv1.CosmosFullNode) kube.ReconcileError {
+	// Patch a copy so the patch response does not overwrite in-memory status changes.
+	obj := crd.DeepCopy()
+	patch := client.MergeFrom(obj.DeepCopy())
+	delete(obj.Annotations, cosmosv1.ResetAnnotation)
+	if err := control.client.Patch(ctx, obj, patch); err != nil {
+		return kube.TransientError(fmt.Errorf("remove reset annotation: %w", err))
+	}
+	return nil
+}
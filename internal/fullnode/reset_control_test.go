@@ -0,0 +1,310 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/test"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type mockInfoReporter struct {
+	test.NopReporter
+	reasons []string
+}
+
+func (r *mockInfoReporter) RecordInfo(reason, _ string) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestResetControl_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	readyPod := func(name string) *corev1.Pod {
+		pod := new(corev1.Pod)
+		pod.Name = name
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}}
+		return pod
+	}
+
+	syncedCollection := func(synced int, names ...string) cosmos.StatusCollection {
+		return lo.Map(names, func(name string, i int) cosmos.StatusItem {
+			item := cosmos.StatusItem{Pod: readyPod(name)}
+			item.Status.Result.SyncInfo.CatchingUp = i >= synced
+			return item
+		})
+	}
+
+	testCRD := func() cosmosv1.CosmosFullNode {
+		crd := defaultCRD()
+		crd.Spec.Replicas = 3
+		crd.Annotations = map[string]string{cosmosv1.ResetAnnotation: "1"}
+		return crd
+	}
+
+	var nopSyncer = mockStatusSyncer(func(context.Context, client.ObjectKey, func(*cosmosv1.FullNodeStatus)) error {
+		return nil
+	})
+
+	testControl := func(mClient *mockClient[*corev1.PersistentVolumeClaim], coll cosmos.StatusCollection) ResetControl {
+		control := NewResetControl(mClient, mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			require.NotNil(t, ctx)
+			require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, controller)
+			return coll
+		}}, nopSyncer)
+		return control
+	}
+
+	existingPVC := func(name string) corev1.PersistentVolumeClaim {
+		var pvc corev1.PersistentVolumeClaim
+		pvc.Name = name
+		pvc.Namespace = "test"
+		pvc.UID = "old-uid"
+		return pvc
+	}
+
+	t.Run("no request", func(t *testing.T) {
+		crd := defaultCRD()
+		var mClient mockClient[*corev1.PersistentVolumeClaim]
+		control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+
+		requeue, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.False(t, requeue)
+		require.Zero(t, mClient.PatchCount)
+		require.Empty(t, crd.Status.Resets)
+	})
+
+	t.Run("accept request", func(t *testing.T) {
+		crd := testCRD()
+		mClient := mockClient[*corev1.PersistentVolumeClaim]{Object: existingPVC("pvc-osmosis-1")}
+		control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+		now := time.Now()
+		control.now = func() time.Time { return now }
+		var gotStatus cosmosv1.FullNodeStatus
+		control.statusClient = mockStatusSyncer(func(ctx context.Context, key client.ObjectKey, update func(*cosmosv1.FullNodeStatus)) error {
+			require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, key)
+			// Persisted before the annotation is removed.
+			require.Zero(t, mClient.PatchCount)
+			update(&gotStatus)
+			return nil
+		})
+
+		requeue, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.True(t, requeue)
+
+		require.Equal(t, "pvc-osmosis-1", mClient.GetObjectKey.Name)
+		require.Zero(t, mClient.DeleteCount)
+
+		want := map[string]cosmosv1.FullNodeResetStatus{
+			"osmosis-1": {PVCName: "pvc-osmosis-1", PVCUID: "old-uid", StartedAt: metav1.NewTime(now)},
+		}
+		require.Equal(t, want, crd.Status.Resets)
+		require.Equal(t, want, gotStatus.Resets)
+
+		require.Equal(t, 1, mClient.PatchCount)
+		patched := mClient.LastPatchObject.(*cosmosv1.CosmosFullNode)
+		require.NotContains(t, patched.Annotations, cosmosv1.ResetAnnotation)
+		// In-memory crd is not mutated by the patch.
+		require.Contains(t, crd.Annotations, cosmosv1.ResetAnnotation)
+
+		_, exists := podCandidates(&crd)["osmosis-1"]
+		require.True(t, exists)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		for _, val := range []string{"", "nope", "-1", "3"} {
+			crd := testCRD()
+			crd.Annotations[cosmosv1.ResetAnnotation] = val
+			var mClient mockClient[*corev1.PersistentVolumeClaim]
+			control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+
+			requeue, err := control.Reconcile(ctx, nopReporter, &crd)
+			require.NoError(t, err, val)
+			require.False(t, requeue, val)
+			require.Empty(t, crd.Status.Resets, val)
+			require.Equal(t, 1, mClient.PatchCount, val)
+		}
+	})
+
+	t.Run("status update error", func(t *testing.T) {
+		crd := testCRD()
+		mClient := mockClient[*corev1.PersistentVolumeClaim]{Object: existingPVC("pvc-osmosis-1")}
+		control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+		control.statusClient = mockStatusSyncer(func(context.Context, client.ObjectKey, func(*cosmosv1.FullNodeStatus)) error {
+			return fmt.Errorf("conflict")
+		})
+
+		_, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.Error(t, err)
+		require.EqualError(t, err, "record reset in status: conflict")
+		require.True(t, err.IsTransient())
+		require.Empty(t, crd.Status.Resets)
+		// The request is retried.
+		require.Zero(t, mClient.PatchCount)
+	})
+
+	t.Run("last in-sync replica", func(t *testing.T) {
+		crd := testCRD()
+		var mClient mockClient[*corev1.PersistentVolumeClaim]
+		control := testControl(&mClient, syncedCollection(1, "osmosis-1", "osmosis-0", "osmosis-2"))
+		var reporter mockInfoReporter
+
+		for i := 0; i < 3; i++ {
+			requeue, err := control.Reconcile(ctx, &reporter, &crd)
+			require.NoError(t, err)
+			require.False(t, requeue)
+		}
+		require.Empty(t, crd.Status.Resets)
+		require.Zero(t, mClient.PatchCount)
+		// Recorded once when waiting starts.
+		require.Equal(t, []string{"ResetWaiting"}, reporter.reasons)
+
+		// A new reason is recorded.
+		control.collector = mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+			return syncedCollection(2, "osmosis-1", "osmosis-0", "osmosis-2")
+		}}
+		control.computeRollout = func(*intstr.IntOrString, int, int) int { return 0 }
+		_, err := control.Reconcile(ctx, &reporter, &crd)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ResetWaiting", "ResetWaiting"}, reporter.reasons)
+	})
+
+	t.Run("max unavailable", func(t *testing.T) {
+		crd := testCRD()
+		crd.Spec.RolloutStrategy.MaxUnavailable = ptr(intstr.FromInt(1))
+		var mClient mockClient[*corev1.PersistentVolumeClaim]
+		control := testControl(&mClient, syncedCollection(2, "osmosis-0", "osmosis-1", "osmosis-2"))
+		control.computeRollout = func(maxUnavail *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, crd.Spec.RolloutStrategy.MaxUnavailable, maxUnavail)
+			require.Equal(t, 3, desired)
+			require.Equal(t, 2, ready)
+			return 0
+		}
+
+		_, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.Empty(t, crd.Status.Resets)
+		require.Zero(t, mClient.PatchCount)
+	})
+
+	t.Run("out of sync target", func(t *testing.T) {
+		crd := testCRD()
+		mClient := mockClient[*corev1.PersistentVolumeClaim]{Object: existingPVC("pvc-osmosis-1")}
+		// Only osmosis-0 is in-sync. The availability guard applies even though osmosis-1 is not available.
+		control := testControl(&mClient, syncedCollection(1, "osmosis-0", "osmosis-1", "osmosis-2"))
+		rollout := 0
+		control.computeRollout = func(_ *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, 3, desired)
+			require.Equal(t, 1, ready)
+			return rollout
+		}
+
+		_, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.Empty(t, crd.Status.Resets)
+		require.Zero(t, mClient.PatchCount)
+
+		rollout = 1
+		_, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.Contains(t, crd.Status.Resets, "osmosis-1")
+		require.Equal(t, 1, mClient.PatchCount)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		inProgress := func() cosmosv1.CosmosFullNode {
+			crd := defaultCRD()
+			crd.Spec.Replicas = 3
+			crd.Status.Resets = map[string]cosmosv1.FullNodeResetStatus{
+				"osmosis-1": {PVCName: "pvc-osmosis-1", PVCUID: "old-uid"},
+			}
+			return crd
+		}
+
+		// Pod not deleted yet.
+		crd := inProgress()
+		var mClient mockClient[*corev1.PersistentVolumeClaim]
+		control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+		requeue, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Zero(t, mClient.DeleteCount)
+		require.Len(t, crd.Status.Resets, 1)
+
+		// Pod deleted; delete the pvc.
+		mClient = mockClient[*corev1.PersistentVolumeClaim]{Object: existingPVC("pvc-osmosis-1")}
+		control = testControl(&mClient, syncedCollection(2, "osmosis-0", "osmosis-2"))
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Equal(t, 1, mClient.DeleteCount)
+		require.Len(t, crd.Status.Resets, 1)
+
+		// Pvc deleting.
+		pvc := existingPVC("pvc-osmosis-1")
+		pvc.DeletionTimestamp = ptr(metav1.Now())
+		mClient = mockClient[*corev1.PersistentVolumeClaim]{Object: pvc}
+		control = testControl(&mClient, syncedCollection(2, "osmosis-0", "osmosis-2"))
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Zero(t, mClient.DeleteCount)
+
+		// Pvc deleted; waiting for PVCControl to recreate.
+		mClient = mockClient[*corev1.PersistentVolumeClaim]{GetObjectErr: apierrors.NewNotFound(schema.GroupResource{}, "pvc-osmosis-1")}
+		control = testControl(&mClient, syncedCollection(2, "osmosis-0", "osmosis-2"))
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.True(t, requeue)
+		require.Len(t, crd.Status.Resets, 1)
+
+		// Pvc recreated.
+		pvc = existingPVC("pvc-osmosis-1")
+		pvc.UID = "new-uid"
+		mClient = mockClient[*corev1.PersistentVolumeClaim]{Object: pvc}
+		control = testControl(&mClient, syncedCollection(2, "osmosis-0", "osmosis-2"))
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.False(t, requeue)
+		require.Empty(t, crd.Status.Resets)
+
+		// Scaled down.
+		crd = inProgress()
+		crd.Spec.Replicas = 1
+		control = testControl(&mockClient[*corev1.PersistentVolumeClaim]{}, syncedCollection(1, "osmosis-0"))
+		requeue, err = control.Reconcile(ctx, nopReporter, &crd)
+		require.NoError(t, err)
+		require.False(t, requeue)
+		require.Empty(t, crd.Status.Resets)
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		crd := testCRD()
+		mClient := mockClient[*corev1.PersistentVolumeClaim]{GetObjectErr: fmt.Errorf("boom")}
+		control := testControl(&mClient, syncedCollection(3, "osmosis-0", "osmosis-1", "osmosis-2"))
+
+		_, err := control.Reconcile(ctx, nopReporter, &crd)
+		require.Error(t, err)
+		require.True(t, err.IsTransient())
+		require.Zero(t, mClient.PatchCount)
+	})
+}
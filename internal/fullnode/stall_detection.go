@@ -0,0 +1,180 @@
+package fullnode
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultStallDuration    = 10 * time.Minute
+	defaultStallBackoff     = 5 * time.Minute
+	defaultStallMaxAttempts = 3
+	maxStallBackoff         = 24 * time.Hour
+)
+
+// StalledPod is a pod whose height has not advanced while the reference height is greater.
+type StalledPod struct {
+	Pod *corev1.Pod
+	// The pod's height.
+	Height uint64
+	// The max height of the pod's siblings or the reference RPCs.
+	ReferenceHeight uint64
+	// When the pod's height last advanced.
+	Since time.Time
+}
+
+// StallResult is the outcome of StallDetection.
+type StallResult struct {
+	// Stalled pods to delete, limited by the rollout strategy.
+	Restart []StalledPod
+	// Stalled pods that reached max attempts and have not yet been marked exhausted.
+	Exhausted []StalledPod
+	// Instances with stall mitigation status whose height advanced since. Their status should be cleared.
+	Recovered []string
+}
+
+// StallDetection detects pods whose height stopped advancing.
+type StallDetection struct {
+	available      func(pods []*corev1.Pod, minReady time.Duration, now time.Time) []*corev1.Pod
+	collector      StatusCollector
+	computeRollout func(maxUnavail *intstr.IntOrString, desired, ready int) int
+	now            func() time.Time
+}
+
+func NewStallDetection(collector StatusCollector) StallDetection {
+	return StallDetection{
+		available:      kube.AvailablePods,
+		collector:      collector,
+		computeRollout: kube.ComputeRollout,
+		now:            time.Now,
+	}
+}
+
+// Detect returns pods whose height has not advanced for the stall duration while the reference height is greater.
+// Uses crd.Status.SelfHealing.StallMitigation to apply exponential backoff and max attempts per pod.
+// Assumes crd.Spec.SelfHeal.StallMitigation is set or else this method may panic.
+func (d StallDetection) Detect(ctx context.Context, crd *cosmosv1.CosmosFullNode) StallResult {
+	var (
+		spec        = crd.Spec.SelfHeal.StallMitigation
+		stallDur    = durationOrDefault(spec.StallDuration, defaultStallDuration)
+		backoff     = durationOrDefault(spec.Backoff, defaultStallBackoff)
+		maxAttempts = int32(defaultStallMaxAttempts)
+		status      = crd.Status.SelfHealing.StallMitigation
+		now         = d.now()
+		result      StallResult
+	)
+	if spec.MaxAttempts != nil {
+		maxAttempts = *spec.MaxAttempts
+	}
+
+	coll := d.collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+	heights := make(map[string]uint64)
+	for _, item := range coll {
+		if comet, err := item.GetStatus(); err == nil {
+			heights[item.GetPod().Name] = comet.LatestBlockHeight()
+		}
+	}
+
+	var stalled []StalledPod
+	for _, item := range coll {
+		pod := item.GetPod()
+		height, ok := heights[pod.Name]
+		if !ok || height == 0 {
+			continue
+		}
+
+		prev := status[pod.Name]
+		if prev != nil && height > prev.Height {
+			result.Recovered = append(result.Recovered, pod.Name)
+			prev = nil
+		}
+
+		var ref uint64
+		if item.Reference != nil {
+			ref = item.Reference.Height
+		}
+		for name, h := range heights {
+			if name != pod.Name {
+				ref = max(ref, h)
+			}
+		}
+		if isDebugPod(pod) || item.HeightChangedAt.IsZero() || now.Sub(item.HeightChangedAt) < stallDur || ref <= height {
+			continue
+		}
+
+		sp := StalledPod{Pod: pod, Height: height, ReferenceHeight: ref, Since: item.HeightChangedAt}
+		if prev == nil {
+			stalled = append(stalled, sp)
+			continue
+		}
+		if prev.Exhausted {
+			continue
+		}
+		if prev.Attempts >= maxAttempts {
+			result.Exhausted = append(result.Exhausted, sp)
+			continue
+		}
+		if now.Sub(prev.LastAttempt.Time) >= stallBackoff(backoff, prev.Attempts) {
+			stalled = append(stalled, sp)
+		}
+	}
+
+	// Only in-sync pods count as available, so restarts never leave fewer in-sync replicas than the rollout allows.
+	avail := d.available(coll.SyncedPods(), 5*time.Second, now)
+	rollout := d.computeRollout(crd.Spec.RolloutStrategy.MaxUnavailable, int(crd.Spec.Replicas), len(avail))
+	result.Restart = lo.Slice(stalled, 0, rollout)
+	return result
+}
+
+// stallBackoff returns backoff doubled for each attempt after the first, capped at maxStallBackoff.
+func stallBackoff(backoff time.Duration, attempts int32) time.Duration {
+	wait := backoff
+	for i := int32(1); i < attempts && wait > 0 && wait < maxStallBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, maxStallBackoff)
+}
+
+// ApplyStallResult updates status after the restarted pods were deleted.
+func ApplyStallResult(status *cosmosv1.FullNodeStatus, restarted []StalledPod, result StallResult, now time.Time) {
+	m := status.SelfHealing.StallMitigation
+	for _, name := range result.Recovered {
+		delete(m, name)
+	}
+	if m == nil {
+		m = make(map[string]*cosmosv1.StallMitigationStatus)
+	}
+	for _, sp := range restarted {
+		prev := m[sp.Pod.Name]
+		if prev == nil || prev.Height != sp.Height {
+			prev = &cosmosv1.StallMitigationStatus{Height: sp.Height}
+			m[sp.Pod.Name] = prev
+		}
+		prev.Attempts++
+		prev.LastAttempt = metav1.NewTime(now)
+	}
+	for _, sp := range result.Exhausted {
+		if prev := m[sp.Pod.Name]; prev != nil {
+			prev.Exhausted = true
+		}
+	}
+	if len(m) == 0 {
+		m = nil
+	}
+	status.SelfHealing.StallMitigation = m
+}
+
+func durationOrDefault(d *metav1.Duration, defaultDur time.Duration) time.Duration {
+	if d == nil || d.Duration <= 0 {
+		return defaultDur
+	}
+	return d.Duration
+}
@@ -0,0 +1,257 @@
+package fullnode
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestStallDetection_Detect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	newColl := func(heights ...string) cosmos.StatusCollection {
+		return lo.Map(heights, func(h string, i int) cosmos.StatusItem {
+			var item cosmos.StatusItem
+			item.Pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("osmosis-%d", i)}}
+			item.Status.Result.SyncInfo.LatestBlockHeight = h
+			item.HeightChangedAt = now.Add(-time.Minute)
+			return item
+		})
+	}
+
+	newDetector := func(coll cosmos.StatusCollection, rollout int) StallDetection {
+		detector := NewStallDetection(mockStatusCollector{CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+			require.NotNil(t, ctx)
+			require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, controller)
+			return coll
+		}})
+		detector.now = func() time.Time { return now }
+		detector.available = func(pods []*corev1.Pod, minReady time.Duration, _ time.Time) []*corev1.Pod {
+			require.Equal(t, 5*time.Second, minReady)
+			return pods
+		}
+		detector.computeRollout = func(_ *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, 3, desired)
+			require.Equal(t, len(coll.SyncedPods()), ready)
+			return rollout
+		}
+		return detector
+	}
+
+	newCRD := func() cosmosv1.CosmosFullNode {
+		crd := defaultCRD()
+		crd.Spec.Replicas = 3
+		crd.Spec.SelfHeal = &cosmosv1.SelfHealSpec{StallMitigation: &cosmosv1.StallMitigationSpec{}}
+		return crd
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		coll := newColl("100", "120", "90")
+		coll[0].HeightChangedAt = now.Add(-11 * time.Minute)
+		coll[2].HeightChangedAt = now.Add(-time.Hour)
+
+		crd := newCRD()
+		got := newDetector(coll, 3).Detect(context.Background(), &crd)
+
+		require.Len(t, got.Restart, 2)
+		require.Equal(t, "osmosis-0", got.Restart[0].Pod.Name)
+		require.EqualValues(t, 100, got.Restart[0].Height)
+		require.EqualValues(t, 120, got.Restart[0].ReferenceHeight)
+		require.Equal(t, now.Add(-11*time.Minute), got.Restart[0].Since)
+		require.Equal(t, "osmosis-2", got.Restart[1].Pod.Name)
+		require.Empty(t, got.Exhausted)
+		require.Empty(t, got.Recovered)
+
+		// Limited by rollout strategy.
+		got = newDetector(coll, 1).Detect(context.Background(), &crd)
+		require.Len(t, got.Restart, 1)
+	})
+
+	t.Run("not stalled", func(t *testing.T) {
+		for _, tt := range []struct {
+			Name  string
+			Setup func(coll cosmos.StatusCollection)
+		}{
+			{"not long enough", func(coll cosmos.StatusCollection) { coll[0].HeightChangedAt = now.Add(-9 * time.Minute) }},
+			{"no history", func(coll cosmos.StatusCollection) { coll[0].HeightChangedAt = time.Time{} }},
+			{"at reference height", func(coll cosmos.StatusCollection) {
+				coll[0].HeightChangedAt = now.Add(-time.Hour)
+				coll[1].Status.Result.SyncInfo.LatestBlockHeight = "100"
+			}},
+			{"debug pod", func(coll cosmos.StatusCollection) {
+				coll[0].HeightChangedAt = now.Add(-time.Hour)
+				coll[0].Pod.Labels = map[string]string{debugLabel: "true"}
+			}},
+			{"status error", func(coll cosmos.StatusCollection) {
+				coll[0].HeightChangedAt = now.Add(-time.Hour)
+				coll[0].Err = fmt.Errorf("boom")
+			}},
+		} {
+			coll := newColl("100", "120")
+			tt.Setup(coll)
+			crd := newCRD()
+			got := newDetector(coll, 3).Detect(context.Background(), &crd)
+			require.Empty(t, got.Restart, tt.Name)
+		}
+	})
+
+	t.Run("single replica has no reference", func(t *testing.T) {
+		coll := newColl("100")
+		coll[0].HeightChangedAt = now.Add(-time.Hour)
+		crd := newCRD()
+		detector := newDetector(coll, 1)
+		detector.computeRollout = func(*intstr.IntOrString, int, int) int { return 1 }
+
+		require.Empty(t, detector.Detect(context.Background(), &crd).Restart)
+	})
+
+	t.Run("reference height", func(t *testing.T) {
+		coll := newColl("100")
+		coll[0].HeightChangedAt = now.Add(-time.Hour)
+		coll[0].Reference = &cosmos.ReferenceTip{Height: 150, MaxLag: 10}
+		crd := newCRD()
+		detector := newDetector(coll, 1)
+		detector.computeRollout = func(*intstr.IntOrString, int, int) int { return 1 }
+
+		got := detector.Detect(context.Background(), &crd)
+		require.Len(t, got.Restart, 1)
+		require.EqualValues(t, 150, got.Restart[0].ReferenceHeight)
+	})
+
+	t.Run("backoff and max attempts", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.SelfHeal.StallMitigation.StallDuration = &metav1.Duration{Duration: time.Minute}
+		crd.Spec.SelfHeal.StallMitigation.MaxAttempts = ptr(int32(3))
+
+		for _, tt := range []struct {
+			Name          string
+			Status        cosmosv1.StallMitigationStatus
+			WantRestart   bool
+			WantExhausted bool
+		}{
+			{"first backoff elapsed", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 1, LastAttempt: metav1.NewTime(now.Add(-6 * time.Minute))}, true, false},
+			{"first backoff", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 1, LastAttempt: metav1.NewTime(now.Add(-4 * time.Minute))}, false, false},
+			{"second backoff", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 2, LastAttempt: metav1.NewTime(now.Add(-9 * time.Minute))}, false, false},
+			{"second backoff elapsed", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 2, LastAttempt: metav1.NewTime(now.Add(-10 * time.Minute))}, true, false},
+			{"max attempts", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 3, LastAttempt: metav1.NewTime(now.Add(-time.Hour))}, false, true},
+			{"already exhausted", cosmosv1.StallMitigationStatus{Height: 100, Attempts: 3, Exhausted: true}, false, false},
+			{"no attempts", cosmosv1.StallMitigationStatus{Height: 100, LastAttempt: metav1.NewTime(now.Add(-6 * time.Minute))}, true, false},
+		} {
+			coll := newColl("100", "120")
+			coll[0].HeightChangedAt = now.Add(-2 * time.Minute)
+			status := tt.Status
+			crd.Status.SelfHealing.StallMitigation = map[string]*cosmosv1.StallMitigationStatus{"osmosis-0": &status}
+
+			got := newDetector(coll, 3).Detect(context.Background(), &crd)
+
+			require.Equal(t, tt.WantRestart, len(got.Restart) == 1, tt.Name)
+			require.Equal(t, tt.WantExhausted, len(got.Exhausted) == 1, tt.Name)
+			require.Empty(t, got.Recovered, tt.Name)
+		}
+	})
+
+	t.Run("max backoff", func(t *testing.T) {
+		crd := newCRD()
+		crd.Spec.SelfHeal.StallMitigation.StallDuration = &metav1.Duration{Duration: time.Minute}
+		crd.Spec.SelfHeal.StallMitigation.MaxAttempts = ptr(int32(1000))
+
+		for _, tt := range []struct {
+			LastAttempt time.Duration
+			WantRestart bool
+		}{
+			{-23 * time.Hour, false},
+			{-24 * time.Hour, true},
+		} {
+			coll := newColl("100", "120")
+			coll[0].HeightChangedAt = now.Add(-2 * time.Minute)
+			crd.Status.SelfHealing.StallMitigation = map[string]*cosmosv1.StallMitigationStatus{
+				"osmosis-0": {Height: 100, Attempts: 500, LastAttempt: metav1.NewTime(now.Add(tt.LastAttempt))},
+			}
+
+			got := newDetector(coll, 3).Detect(context.Background(), &crd)
+
+			require.Equal(t, tt.WantRestart, len(got.Restart) == 1, tt.LastAttempt)
+		}
+	})
+
+	t.Run("only in-sync pods are available", func(t *testing.T) {
+		coll := newColl("100", "120", "90")
+		coll[0].HeightChangedAt = now.Add(-time.Hour)
+		coll[2].Status.Result.SyncInfo.CatchingUp = true
+
+		crd := newCRD()
+		detector := newDetector(coll, 1)
+		detector.computeRollout = func(_ *intstr.IntOrString, desired, ready int) int {
+			require.Equal(t, 2, ready)
+			return 1
+		}
+		got := detector.Detect(context.Background(), &crd)
+		require.Len(t, got.Restart, 1)
+	})
+
+	t.Run("recovered", func(t *testing.T) {
+		coll := newColl("100", "120")
+		crd := newCRD()
+		crd.Status.SelfHealing.StallMitigation = map[string]*cosmosv1.StallMitigationStatus{
+			"osmosis-0": {Height: 90, Attempts: 3, Exhausted: true},
+			"osmosis-1": {Height: 120, Attempts: 1},
+		}
+
+		got := newDetector(coll, 3).Detect(context.Background(), &crd)
+		require.Equal(t, []string{"osmosis-0"}, got.Recovered)
+
+		// Stalled again at a new height starts over.
+		coll[0].HeightChangedAt = now.Add(-time.Hour)
+		got = newDetector(coll, 3).Detect(context.Background(), &crd)
+		require.Equal(t, []string{"osmosis-0"}, got.Recovered)
+		require.Len(t, got.Restart, 1)
+	})
+}
+
+func TestApplyStallResult(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	var status cosmosv1.FullNodeStatus
+	status.SelfHealing.StallMitigation = map[string]*cosmosv1.StallMitigationStatus{
+		"osmosis-0": {Height: 90, Attempts: 3},
+		"osmosis-1": {Height: 100, Attempts: 1},
+		"osmosis-2": {Height: 100, Attempts: 3},
+	}
+
+	result := StallResult{
+		Exhausted: []StalledPod{{Pod: pod("osmosis-2"), Height: 100}},
+		Recovered: []string{"osmosis-0"},
+	}
+	restarted := []StalledPod{
+		{Pod: pod("osmosis-1"), Height: 100},
+		{Pod: pod("osmosis-3"), Height: 50},
+	}
+	ApplyStallResult(&status, restarted, result, now)
+
+	want := map[string]*cosmosv1.StallMitigationStatus{
+		"osmosis-1": {Height: 100, Attempts: 2, LastAttempt: metav1.NewTime(now)},
+		"osmosis-2": {Height: 100, Attempts: 3, Exhausted: true},
+		"osmosis-3": {Height: 50, Attempts: 1, LastAttempt: metav1.NewTime(now)},
+	}
+	require.Equal(t, want, status.SelfHealing.StallMitigation)
+
+	ApplyStallResult(&status, nil, StallResult{Recovered: []string{"osmosis-1", "osmosis-2", "osmosis-3"}}, now)
+	require.Nil(t, status.SelfHealing.StallMitigation)
+}
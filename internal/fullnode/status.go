@@ -2,9 +2,17 @@ package fullnode
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -48,3 +56,175 @@ func SyncInfoStatus(
 
 	return status
 }
+
+// InstanceStatus returns details of the node running in each of the full node's pods.
+func InstanceStatus(
+	ctx context.Context,
+	crd *cosmosv1.CosmosFullNode,
+	collector StatusCollector,
+) map[string]*cosmosv1.InstanceStatus {
+	status := make(map[string]*cosmosv1.InstanceStatus, crd.Spec.Replicas)
+
+	coll := collector.Collect(ctx, client.ObjectKeyFromObject(crd))
+	tip, chainRate := chainTip(coll)
+
+	for _, item := range coll {
+		var (
+			stat cosmosv1.InstanceStatus
+			pod  = item.GetPod()
+		)
+		stat.Timestamp = metav1.NewTime(item.Timestamp())
+		stat.Image = runningImage(pod)
+		for _, cs := range pod.Status.ContainerStatuses {
+			stat.Restarts += cs.RestartCount
+		}
+		if cl := ClassifyCrashLoop(pod); cl != nil {
+			stat.CrashLoop = &cl.Status
+		}
+		stat.Conditions = divergedConditions(crd.Status.Instances[pod.Name], item.Divergence)
+		setWrongNetworkCondition(&stat.Conditions, item.Err)
+		status[pod.Name] = &stat
+
+		comet, err := item.GetStatus()
+		if err != nil {
+			stat.Error = ptr(err.Error())
+			continue
+		}
+		var (
+			nodeInfo = comet.Result.NodeInfo
+			syncInfo = comet.Result.SyncInfo
+		)
+		stat.NodeID = nodeInfo.ID
+		stat.CometBFTVersion = nodeInfo.Version
+		stat.Network = nodeInfo.Network
+		if h, err := strconv.ParseUint(syncInfo.EarliestBlockHeight, 10, 64); err == nil {
+			stat.EarliestHeight = ptr(h)
+		}
+		if h := comet.LatestBlockHeight(); h > 0 {
+			stat.LatestHeight = ptr(h)
+		}
+		if !syncInfo.LatestBlockTime.IsZero() {
+			stat.LatestBlockTime = ptr(metav1.NewTime(syncInfo.LatestBlockTime))
+			stat.BlockAge = &metav1.Duration{Duration: item.Timestamp().Sub(syncInfo.LatestBlockTime).Round(time.Second)}
+		}
+		if item.Details != nil {
+			stat.AppVersion = item.Details.AppVersion
+			stat.Peers = ptr(int32(item.Details.Peers))
+			stat.MempoolSize = ptr(int32(item.Details.MempoolSize))
+		}
+		stat.CatchingUp = syncInfo.CatchingUp
+		if item.SyncRate != nil {
+			stat.SyncRate = strconv.FormatFloat(item.SyncRate.BlocksPerSecond, 'f', 2, 64)
+			if stat.CatchingUp {
+				stat.CatchUpETA = catchUpETA(*item.SyncRate, chainRate, tip, comet.LatestBlockHeight())
+			}
+		}
+	}
+
+	return status
+}
+
+// chainTip returns the highest height in the collection and the chain's block production rate in blocks per second.
+// The rate is measured by in sync pods when possible, otherwise by the block times seen by catching up pods.
+func chainTip(coll cosmos.StatusCollection) (tip uint64, chainRate float64) {
+	var fallback float64
+	for _, item := range coll {
+		comet, err := item.GetStatus()
+		if err != nil {
+			continue
+		}
+		tip = max(tip, comet.LatestBlockHeight())
+		if item.SyncRate == nil {
+			continue
+		}
+		if comet.Result.SyncInfo.CatchingUp {
+			fallback = max(fallback, item.SyncRate.ChainBlocksPerSecond)
+		} else {
+			chainRate = max(chainRate, item.SyncRate.BlocksPerSecond)
+		}
+	}
+	if chainRate == 0 {
+		chainRate = fallback
+	}
+	return tip, chainRate
+}
+
+// catchUpETA estimates how long until height reaches tip while the chain keeps producing blocks at chainRate.
+// Returns nil if the pod is not gaining on the chain.
+func catchUpETA(rate cosmos.SyncRate, chainRate float64, tip, height uint64) *metav1.Duration {
+	gain := rate.BlocksPerSecond - chainRate
+	if tip <= height || gain <= 0 {
+		return nil
+	}
+	eta := time.Duration(float64(tip-height) / gain * float64(time.Second))
+	return &metav1.Duration{Duration: eta.Round(time.Second)}
+}
+
+// ReportSyncStalls records a warning event for each catching up instance whose sync rate dropped to zero
+// since the previous status.
+func ReportSyncStalls(reporter kube.Reporter, prev, cur map[string]*cosmosv1.InstanceStatus) {
+	for name, stat := range cur {
+		before := prev[name]
+		if before == nil || !stat.CatchingUp || syncRate(stat) != 0 || syncRate(before) <= 0 {
+			continue
+		}
+		reporter.RecordError("SyncStalled", fmt.Errorf("%s stopped syncing at height %d while catching up", name, lo.FromPtr(stat.LatestHeight)))
+	}
+}
+
+// setWrongNetworkCondition sets the WrongNetwork condition from the instance's status error. The condition is only
+// cleared once the node's network is verified, and only added once the network is wrong.
+func setWrongNetworkCondition(conds *[]metav1.Condition, statusErr error) {
+	var wrongNetwork *cosmos.WrongNetworkError
+	switch {
+	case errors.As(statusErr, &wrongNetwork):
+		meta.SetStatusCondition(conds, metav1.Condition{
+			Type:    cosmosv1.ConditionWrongNetwork,
+			Status:  metav1.ConditionTrue,
+			Reason:  "ChainIDMismatch",
+			Message: fmt.Sprintf("Node reports chain ID %q but spec.chain.chainID is %q", wrongNetwork.Got, wrongNetwork.Want),
+		})
+	case statusErr == nil && meta.FindStatusCondition(*conds, cosmosv1.ConditionWrongNetwork) != nil:
+		meta.SetStatusCondition(conds, metav1.Condition{
+			Type:    cosmosv1.ConditionWrongNetwork,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ChainIDMatches",
+			Message: "Node reports the expected chain ID",
+		})
+	}
+}
+
+// ReportWrongNetwork records a warning event for each instance whose WrongNetwork condition became true since the
+// previous status.
+func ReportWrongNetwork(reporter kube.Reporter, prev, cur map[string]*cosmosv1.InstanceStatus) {
+	for name, stat := range cur {
+		cond := meta.FindStatusCondition(stat.Conditions, cosmosv1.ConditionWrongNetwork)
+		if cond == nil || cond.Status != metav1.ConditionTrue {
+			continue
+		}
+		if before := prev[name]; before != nil && meta.IsStatusConditionTrue(before.Conditions, cosmosv1.ConditionWrongNetwork) {
+			continue
+		}
+		reporter.RecordError("WrongNetwork", fmt.Errorf("%s: %s", name, cond.Message))
+	}
+}
+
+// syncRate returns the instance's sync rate or -1 if unknown.
+func syncRate(stat *cosmosv1.InstanceStatus) float64 {
+	rate, err := strconv.ParseFloat(stat.SyncRate, 64)
+	if err != nil {
+		return -1
+	}
+	return rate
+}
+
+// runningImage returns the image reported by the node container's status, falling back to the pod spec if the
+// container has not started.
+func runningImage(pod *corev1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == mainContainer && cs.Image != "" {
+			return cs.Image
+		}
+	}
+	return ChainContainerImage(pod)
+}
@@ -8,8 +8,10 @@ import (
 
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/test"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -92,3 +94,239 @@ func TestSyncInfoStatus(t *testing.T) {
 	status := SyncInfoStatus(context.Background(), &crd, collector)
 	require.Equal(t, want, status)
 }
+
+func TestInstanceStatus(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	ts := time.Now()
+	blockTime := ts.Add(-6 * time.Second)
+
+	var collector mockStatusCollector
+	collector.CollectFn = func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+		require.Equal(t, client.ObjectKeyFromObject(&crd), controller)
+
+		var comet cosmos.CometStatus
+		comet.Result.NodeInfo.ID = "abc123"
+		comet.Result.NodeInfo.Version = "0.37.2"
+		comet.Result.NodeInfo.Network = "osmosis-1"
+		comet.Result.SyncInfo.EarliestBlockHeight = "1"
+		comet.Result.SyncInfo.LatestBlockHeight = "10000"
+		comet.Result.SyncInfo.LatestBlockTime = blockTime
+
+		var pod0 corev1.Pod
+		pod0.Name = "osmosis-0"
+		pod0.Spec.Containers = []corev1.Container{{Name: "node", Image: "osmosis:v25"}}
+		pod0.Status.ContainerStatuses = []corev1.ContainerStatus{
+			{Name: "node", Image: "ghcr.io/osmosis:v25", RestartCount: 2},
+			{Name: "healthcheck", RestartCount: 1},
+		}
+
+		var pod1 corev1.Pod
+		pod1.Name = "osmosis-1"
+		pod1.Spec.Containers = []corev1.Container{{Name: "node", Image: "osmosis:v25"}}
+		pod1.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "node",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Reason:   "OOMKilled",
+				ExitCode: 137,
+			}},
+			RestartCount: 5,
+		}}
+
+		return cosmos.StatusCollection{
+			{
+				Pod:     &pod0,
+				Status:  comet,
+				Details: &cosmos.NodeDetails{AppVersion: "v25.0.0", Peers: 12, MempoolSize: 4},
+				TS:      ts,
+			},
+			{Pod: &pod1, Err: errors.New("some error"), TS: ts},
+		}
+	}
+
+	wantTS := metav1.NewTime(ts)
+	want := map[string]*cosmosv1.InstanceStatus{
+		"osmosis-0": {
+			Timestamp:       wantTS,
+			NodeID:          "abc123",
+			CometBFTVersion: "0.37.2",
+			AppVersion:      "v25.0.0",
+			Network:         "osmosis-1",
+			EarliestHeight:  ptr(uint64(1)),
+			LatestHeight:    ptr(uint64(10000)),
+			LatestBlockTime: ptr(metav1.NewTime(blockTime)),
+			BlockAge:        &metav1.Duration{Duration: 6 * time.Second},
+			Peers:           ptr(int32(12)),
+			MempoolSize:     ptr(int32(4)),
+			Image:           "ghcr.io/osmosis:v25",
+			Restarts:        3,
+		},
+		"osmosis-1": {
+			Timestamp: wantTS,
+			Image:     "osmosis:v25",
+			Restarts:  5,
+			Error:     ptr("some error"),
+			CrashLoop: &cosmosv1.InstanceCrashLoopStatus{Class: cosmosv1.CrashLoopOOMKilled, Reason: "OOMKilled", ExitCode: 137},
+		},
+	}
+
+	got := InstanceStatus(context.Background(), &crd, collector)
+	require.Equal(t, want, got)
+}
+
+func TestInstanceStatus_CatchUpETA(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+	ts := time.Now()
+
+	item := func(name string, height string, catchingUp bool, rate *cosmos.SyncRate) cosmos.StatusItem {
+		var comet cosmos.CometStatus
+		comet.Result.SyncInfo.LatestBlockHeight = height
+		comet.Result.SyncInfo.CatchingUp = catchingUp
+		var pod corev1.Pod
+		pod.Name = name
+		return cosmos.StatusItem{Pod: &pod, Status: comet, SyncRate: rate, TS: ts}
+	}
+
+	for _, tt := range []struct {
+		Name    string
+		Coll    cosmos.StatusCollection
+		WantETA *metav1.Duration
+	}{
+		{
+			"chain rate from in sync pod",
+			cosmos.StatusCollection{
+				item("osmosis-0", "10000", false, &cosmos.SyncRate{BlocksPerSecond: 0.2}),
+				item("osmosis-1", "1000", true, &cosmos.SyncRate{BlocksPerSecond: 10.2, ChainBlocksPerSecond: 0.5}),
+			},
+			&metav1.Duration{Duration: 900 * time.Second},
+		},
+		{
+			"chain rate from block times",
+			cosmos.StatusCollection{
+				item("osmosis-0", "10000", true, nil),
+				item("osmosis-1", "1000", true, &cosmos.SyncRate{BlocksPerSecond: 10.5, ChainBlocksPerSecond: 0.5}),
+			},
+			&metav1.Duration{Duration: 900 * time.Second},
+		},
+		{
+			"not gaining",
+			cosmos.StatusCollection{
+				item("osmosis-0", "10000", false, &cosmos.SyncRate{BlocksPerSecond: 0.2}),
+				item("osmosis-1", "1000", true, &cosmos.SyncRate{BlocksPerSecond: 0.1}),
+			},
+			nil,
+		},
+		{
+			"highest instance",
+			cosmos.StatusCollection{
+				item("osmosis-1", "1000", true, &cosmos.SyncRate{BlocksPerSecond: 10}),
+			},
+			nil,
+		},
+	} {
+		collector := mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+			return tt.Coll
+		}}
+		got := InstanceStatus(context.Background(), &crd, collector)
+
+		stat := got["osmosis-1"]
+		require.True(t, stat.CatchingUp, tt.Name)
+		require.NotEmpty(t, stat.SyncRate, tt.Name)
+		require.Equal(t, tt.WantETA, stat.CatchUpETA, tt.Name)
+	}
+}
+
+type mockEventReporter struct {
+	test.NopReporter
+	reasons []string
+}
+
+func (r *mockEventReporter) RecordError(reason string, _ error) {
+	r.reasons = append(r.reasons, reason)
+}
+
+func TestReportSyncStalls(t *testing.T) {
+	t.Parallel()
+
+	stat := func(rate string, catchingUp bool) *cosmosv1.InstanceStatus {
+		return &cosmosv1.InstanceStatus{SyncRate: rate, CatchingUp: catchingUp, LatestHeight: ptr(uint64(100))}
+	}
+
+	for _, tt := range []struct {
+		Name      string
+		Prev, Cur *cosmosv1.InstanceStatus
+		WantEvent bool
+	}{
+		{"dropped to zero", stat("12.50", true), stat("0.00", true), true},
+		{"still stalled", stat("0.00", true), stat("0.00", true), false},
+		{"still syncing", stat("12.50", true), stat("3.00", true), false},
+		{"in sync", stat("0.20", false), stat("0.00", false), false},
+		{"unknown rate", stat("", true), stat("0.00", true), false},
+		{"new instance", nil, stat("0.00", true), false},
+	} {
+		prev := map[string]*cosmosv1.InstanceStatus{}
+		if tt.Prev != nil {
+			prev["osmosis-0"] = tt.Prev
+		}
+		var reporter mockEventReporter
+		ReportSyncStalls(&reporter, prev, map[string]*cosmosv1.InstanceStatus{"osmosis-0": tt.Cur})
+
+		if tt.WantEvent {
+			require.Equal(t, []string{"SyncStalled"}, reporter.reasons, tt.Name)
+		} else {
+			require.Empty(t, reporter.reasons, tt.Name)
+		}
+	}
+}
+
+func TestInstanceStatus_WrongNetwork(t *testing.T) {
+	t.Parallel()
+
+	crd := defaultCRD()
+
+	var pod corev1.Pod
+	pod.Name = "osmosis-0"
+	coll := cosmos.StatusCollection{{Pod: &pod, Err: &cosmos.WrongNetworkError{Want: "osmosis-1", Got: "osmo-test-5"}}}
+	collector := mockStatusCollector{CollectFn: func(context.Context, client.ObjectKey) cosmos.StatusCollection {
+		return coll
+	}}
+
+	got := InstanceStatus(context.Background(), &crd, collector)
+	require.Equal(t, `wrong network: node reports chain ID "osmo-test-5", expected "osmosis-1"`, *got["osmosis-0"].Error)
+	cond := meta.FindStatusCondition(got["osmosis-0"].Conditions, cosmosv1.ConditionWrongNetwork)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionTrue, cond.Status)
+	require.Equal(t, "ChainIDMismatch", cond.Reason)
+	require.Equal(t, `Node reports chain ID "osmo-test-5" but spec.chain.chainID is "osmosis-1"`, cond.Message)
+
+	var reporter mockEventReporter
+	ReportWrongNetwork(&reporter, crd.Status.Instances, got)
+	require.Equal(t, []string{"WrongNetwork"}, reporter.reasons)
+
+	// Not reported again.
+	reporter.reasons = nil
+	ReportWrongNetwork(&reporter, got, got)
+	require.Empty(t, reporter.reasons)
+
+	// Unchanged while the network is unknown.
+	crd.Status.Instances = got
+	coll[0].Err = errors.New("timeout")
+	got = InstanceStatus(context.Background(), &crd, collector)
+	require.True(t, meta.IsStatusConditionTrue(got["osmosis-0"].Conditions, cosmosv1.ConditionWrongNetwork))
+
+	// Cleared once the network is verified, e.g. after a reset.
+	coll[0].Err = nil
+	got = InstanceStatus(context.Background(), &crd, collector)
+	cond = meta.FindStatusCondition(got["osmosis-0"].Conditions, cosmosv1.ConditionWrongNetwork)
+	require.NotNil(t, cond)
+	require.Equal(t, metav1.ConditionFalse, cond.Status)
+
+	// Never added if the network is correct.
+	crd.Status.Instances = nil
+	got = InstanceStatus(context.Background(), &crd, collector)
+	require.Empty(t, got["osmosis-0"].Conditions)
+}
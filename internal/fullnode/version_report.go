@@ -0,0 +1,69 @@
+package fullnode
+
+import (
+	"context"
+	"encoding/json"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VersionReport is what the version check reports on its pod's kube.VersionReportAnnotation while the node is stopped.
+// Reporting on the pod instead of the CosmosFullNode status avoids conflicts with the controller's status updates.
+// The version check removes the annotation once the node runs, so the node's RPC is the source of truth for its height.
+type VersionReport struct {
+	// Height the node processes next, i.e. its latest committed height + 1.
+	Height uint64 `json:"height"`
+	// Upgrade from the node's data/upgrade-info.json, if any.
+	Upgrade *cosmosv1.UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// VersionReportFromPod returns the version report annotated on pod, if any.
+func VersionReportFromPod(pod *corev1.Pod) (VersionReport, bool) {
+	var report VersionReport
+	val, ok := pod.Annotations[kube.VersionReportAnnotation]
+	if !ok || json.Unmarshal([]byte(val), &report) != nil || report.Height == 0 {
+		return report, false
+	}
+	return report, true
+}
+
+// VersionReports returns the version reports of the full node's pods keyed by instance name.
+func VersionReports(ctx context.Context, crd *cosmosv1.CosmosFullNode, collector StatusCollector) map[string]VersionReport {
+	reports := make(map[string]VersionReport)
+	for _, item := range collector.Collect(ctx, client.ObjectKeyFromObject(crd)) {
+		pod := item.GetPod()
+		if report, ok := VersionReportFromPod(pod); ok {
+			reports[pod.Name] = report
+		}
+	}
+	return reports
+}
+
+// ApplyVersionReports records the reported heights and upgrades in status.
+// A replaced upgrade with an image in upgradeImages is kept as applied once the instance passed it.
+func ApplyVersionReports(status *cosmosv1.FullNodeStatus, upgradeImages map[string]string, reports map[string]VersionReport) {
+	for name, report := range reports {
+		if status.Height == nil {
+			status.Height = make(map[string]uint64)
+		}
+		status.Height[name] = report.Height
+		if report.Upgrade == nil {
+			continue
+		}
+		if status.Upgrades == nil {
+			status.Upgrades = make(map[string]cosmosv1.UpgradeStatus)
+		}
+		prev, ok := status.Upgrades[name]
+		if _, mapped := upgradeImages[prev.Name]; ok && mapped && prev.Name != report.Upgrade.Name && report.Height > prev.Height {
+			if status.AppliedUpgrades == nil {
+				status.AppliedUpgrades = make(map[string]cosmosv1.UpgradeStatus)
+			}
+			prev.Pending = false
+			status.AppliedUpgrades[name] = prev
+		}
+		status.Upgrades[name] = *report.Upgrade
+	}
+}
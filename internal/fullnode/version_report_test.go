@@ -0,0 +1,62 @@
+package fullnode
+
+import (
+	"context"
+	"testing"
+
+	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestVersionReports(t *testing.T) {
+	t.Parallel()
+
+	newPod := func(name, report string) *corev1.Pod {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if report != "" {
+			pod.Annotations = map[string]string{kube.VersionReportAnnotation: report}
+		}
+		return pod
+	}
+
+	crd := defaultCRD()
+	coll := cosmos.StatusCollection{
+		{Pod: newPod("osmosis-0", `{"height":100}`)},
+		{Pod: newPod("osmosis-1", `{"height":200,"upgrade":{"name":"v2","height":200,"pending":true}}`)},
+		{Pod: newPod("osmosis-2", "")},
+		{Pod: newPod("osmosis-3", `{`)},
+		{Pod: newPod("osmosis-4", `{}`)},
+	}
+	collector := mockStatusCollector{CollectFn: func(_ context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+		require.Equal(t, client.ObjectKey{Namespace: "test", Name: "osmosis"}, controller)
+		return coll
+	}}
+
+	reports := VersionReports(context.Background(), &crd, collector)
+
+	upgrade := cosmosv1.UpgradeStatus{Name: "v2", Height: 200, Pending: true}
+	require.Equal(t, map[string]VersionReport{
+		"osmosis-0": {Height: 100},
+		"osmosis-1": {Height: 200, Upgrade: &upgrade},
+	}, reports)
+
+	crd.Status.Height = map[string]uint64{"osmosis-0": 50, "osmosis-2": 300}
+	ApplyVersionReports(&crd.Status, nil, reports)
+
+	require.Equal(t, map[string]uint64{"osmosis-0": 100, "osmosis-1": 200, "osmosis-2": 300}, crd.Status.Height)
+	require.Equal(t, map[string]cosmosv1.UpgradeStatus{"osmosis-1": upgrade}, crd.Status.Upgrades)
+	require.Empty(t, crd.Status.AppliedUpgrades)
+
+	// A passed upgrade with an image is kept once replaced.
+	next := cosmosv1.UpgradeStatus{Name: "v3", Height: 300, Pending: true}
+	ApplyVersionReports(&crd.Status, map[string]string{"v2": "osmosis:v2"}, map[string]VersionReport{
+		"osmosis-1": {Height: 300, Upgrade: &next},
+	})
+	require.Equal(t, map[string]cosmosv1.UpgradeStatus{"osmosis-1": next}, crd.Status.Upgrades)
+	require.Equal(t, map[string]cosmosv1.UpgradeStatus{"osmosis-1": {Name: "v2", Height: 200}}, crd.Status.AppliedUpgrades)
+}
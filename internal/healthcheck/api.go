@@ -0,0 +1,89 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// APIChecker checks that one of the node's API servers responds.
+type APIChecker interface {
+	CheckAPI(ctx context.Context) error
+}
+
+// getNodeInfoMethod is a cheap query served by the Cosmos SDK gRPC server without consulting app state.
+const getNodeInfoMethod = "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo"
+
+// GRPCChecker checks the node's Cosmos SDK gRPC server.
+type GRPCChecker struct {
+	invoke func(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error
+	close  func() error
+}
+
+// NewGRPCChecker returns a GRPCChecker for the gRPC server at addr, e.g. localhost:9090.
+// The connection is established lazily and reused. Call Close when done.
+func NewGRPCChecker(addr string) (*GRPCChecker, error) {
+	conn, err := grpc.DialContext(context.Background(), addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	return &GRPCChecker{invoke: conn.Invoke, close: conn.Close}, nil
+}
+
+// CheckAPI implements APIChecker.
+func (c *GRPCChecker) CheckAPI(ctx context.Context) error {
+	// The response is decoded into Empty to avoid depending on the SDK's generated types; its fields are ignored.
+	if err := c.invoke(ctx, getNodeInfoMethod, &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
+	return nil
+}
+
+// Close closes the gRPC connection.
+func (c *GRPCChecker) Close() error { return c.close() }
+
+// RESTChecker checks the node's Cosmos SDK REST API server.
+type RESTChecker struct {
+	httpDo func(req *http.Request) (*http.Response, error)
+	url    string
+}
+
+// NewRESTChecker returns a RESTChecker for the REST API at host, e.g. http://localhost:1317.
+func NewRESTChecker(client *http.Client, host string) *RESTChecker {
+	return &RESTChecker{
+		httpDo: client.Do,
+		url:    host + "/cosmos/base/tendermint/v1beta1/syncing",
+	}
+}
+
+// CheckAPI implements APIChecker. Returns an error if the API is unreachable or reports the node is syncing.
+func (c *RESTChecker) CheckAPI(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("rest: new request: %w", err)
+	}
+	resp, err := c.httpDo(req)
+	if err != nil {
+		return fmt.Errorf("rest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("rest: unexpected status %d", resp.StatusCode)
+	}
+	var body struct {
+		Syncing bool `json:"syncing"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("rest: malformed json: %w", err)
+	}
+	if body.Syncing {
+		return errors.New("rest: node is syncing")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestGRPCChecker_CheckAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	checker, err := NewGRPCChecker("localhost:9090")
+	require.NoError(t, err)
+	defer func() { _ = checker.Close() }()
+
+	var gotMethod string
+	checker.invoke = func(ctx context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+		require.NotNil(t, ctx)
+		require.IsType(t, &emptypb.Empty{}, args)
+		require.IsType(t, &emptypb.Empty{}, reply)
+		gotMethod = method
+		return nil
+	}
+
+	require.NoError(t, checker.CheckAPI(ctx))
+	require.Equal(t, "/cosmos.base.tendermint.v1beta1.Service/GetNodeInfo", gotMethod)
+
+	checker.invoke = func(context.Context, string, any, any, ...grpc.CallOption) error {
+		return errors.New("unavailable")
+	}
+	require.EqualError(t, checker.CheckAPI(ctx), "grpc: unavailable")
+}
+
+func TestRESTChecker_CheckAPI(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	stubResp := func(code int, body string) func(req *http.Request) (*http.Response, error) {
+		return func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "http://localhost:1317/cosmos/base/tendermint/v1beta1/syncing", req.URL.String())
+			require.Equal(t, "GET", req.Method)
+			return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		checker := NewRESTChecker(&http.Client{}, "http://localhost:1317")
+		checker.httpDo = stubResp(http.StatusOK, `{"syncing":false}`)
+
+		require.NoError(t, checker.CheckAPI(ctx))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tt := range []struct {
+			HTTPDo  func(req *http.Request) (*http.Response, error)
+			WantErr string
+		}{
+			{stubResp(http.StatusOK, `{"syncing":true}`), "rest: node is syncing"},
+			{stubResp(http.StatusNotImplemented, `{}`), "rest: unexpected status 501"},
+			{stubResp(http.StatusOK, `{`), "rest: malformed json: unexpected EOF"},
+			{func(*http.Request) (*http.Response, error) { return nil, errors.New("boom") }, "rest: boom"},
+		} {
+			checker := NewRESTChecker(&http.Client{}, "http://localhost:1317")
+			checker.httpDo = tt.HTTPDo
+
+			require.EqualError(t, checker.CheckAPI(ctx), tt.WantErr)
+		}
+	})
+}
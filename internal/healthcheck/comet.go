@@ -5,12 +5,16 @@ package healthcheck
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"slices"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"golang.org/x/sync/errgroup"
 )
 
 // Statuser can query the Comet status endpoint.
@@ -19,18 +23,41 @@ type Statuser interface {
 }
 
 type healthResponse struct {
-	Address string `json:"address"`
-	InSync  bool   `json:"in_sync"`
-	Error   string `json:"error,omitempty"`
+	Address         string     `json:"address"`
+	InSync          bool       `json:"in_sync"`
+	Height          uint64     `json:"height,omitempty"`
+	ReferenceHeight uint64     `json:"reference_height,omitempty"`
+	LatestBlockTime *time.Time `json:"latest_block_time,omitempty"`
+	Error           string     `json:"error,omitempty"`
 }
 
+const (
+	// referenceRefresh is how often reference RPCs are queried. Probes typically run every 10s.
+	referenceRefresh = 15 * time.Second
+	// referenceTimeout bounds querying all reference RPCs, leaving most of the probe's timeout for the node.
+	referenceTimeout = 2 * time.Second
+)
+
 // Comet checks the CometBFT status endpoint to determine if the node is in-sync or not.
+// ServeHTTP is the readiness check. Liveness is the liveness and startup check.
 type Comet struct {
-	client     Statuser
-	lastStatus int32
-	logger     logr.Logger
-	rpcHost    string
-	timeout    time.Duration
+	client         Statuser
+	lastStatus     int32
+	lastLiveStatus int32
+	logger         logr.Logger
+	rpcHost        string
+	timeout        time.Duration
+
+	chainID     string
+	references  []string
+	maxLag      uint64
+	maxBlockAge time.Duration
+	apis        []APIChecker
+	now         func() time.Time
+
+	mu              sync.Mutex
+	referenceHeight uint64
+	referenceAt     time.Time
 }
 
 func NewComet(logger logr.Logger, client Statuser, rpcHost string, timeout time.Duration) *Comet {
@@ -39,10 +66,40 @@ func NewComet(logger logr.Logger, client Statuser, rpcHost string, timeout time.
 		logger:  logger,
 		rpcHost: rpcHost,
 		timeout: timeout,
+		now:     time.Now,
 	}
 }
 
-// ServeHTTP implements http.Handler.
+// WithChainID configures the chain ID the node must report as its network. The node is not ready on a mismatch,
+// such as from a wrong genesis or snapshot. Empty disables the check.
+func (h *Comet) WithChainID(chainID string) *Comet {
+	h.chainID = chainID
+	return h
+}
+
+// WithReferences configures reference RPCs that establish the chain tip. The node is not in sync if its height
+// lags the max height of the references by more than maxLag. Unreachable references are ignored.
+func (h *Comet) WithReferences(references []string, maxLag uint64) *Comet {
+	h.references = references
+	h.maxLag = maxLag
+	return h
+}
+
+// WithMaxBlockAge configures the max age of the node's latest block time. The node is not in sync if its latest
+// block is older, which catches nodes that are stuck yet do not report catching up. Zero disables the check.
+func (h *Comet) WithMaxBlockAge(maxAge time.Duration) *Comet {
+	h.maxBlockAge = maxAge
+	return h
+}
+
+// WithAPIs configures API servers that must respond for the node to be ready, such as the gRPC and REST APIs.
+func (h *Comet) WithAPIs(apis ...APIChecker) *Comet {
+	h.apis = apis
+	return h
+}
+
+// ServeHTTP implements http.Handler. It is the readiness check: the node must be on the configured chain, in sync,
+// its latest block recent, its height close to the references, and its API servers responding.
 func (h *Comet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var resp healthResponse
 	resp.Address = h.rpcHost
@@ -53,25 +110,116 @@ func (h *Comet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	status, err := h.client.Status(ctx, h.rpcHost)
 	if err != nil {
 		resp.Error = err.Error()
-		h.writeResponse(http.StatusServiceUnavailable, w, resp)
+		h.writeResponse(&h.lastStatus, http.StatusServiceUnavailable, w, resp)
+		return
+	}
+
+	if network := status.Result.NodeInfo.Network; h.chainID != "" && network != h.chainID {
+		resp.Error = fmt.Sprintf("wrong network: node reports chain ID %q, expected %q", network, h.chainID)
+		h.writeResponse(&h.lastStatus, http.StatusUnprocessableEntity, w, resp)
 		return
 	}
 
 	resp.InSync = !status.Result.SyncInfo.CatchingUp
 	if !resp.InSync {
-		h.writeResponse(http.StatusUnprocessableEntity, w, resp)
+		h.writeResponse(&h.lastStatus, http.StatusUnprocessableEntity, w, resp)
 		return
 	}
 
-	h.writeResponse(http.StatusOK, w, resp)
+	if h.maxBlockAge > 0 {
+		blockTime := status.Result.SyncInfo.LatestBlockTime
+		resp.LatestBlockTime = &blockTime
+		if age := h.now().Sub(blockTime); age > h.maxBlockAge {
+			resp.InSync = false
+			resp.Error = fmt.Sprintf("latest block is %s old, exceeds max age %s", age.Round(time.Second), h.maxBlockAge)
+			h.writeResponse(&h.lastStatus, http.StatusUnprocessableEntity, w, resp)
+			return
+		}
+	}
+
+	if len(h.references) > 0 {
+		resp.Height = status.LatestBlockHeight()
+		resp.ReferenceHeight = h.fetchReferenceHeight()
+		if resp.Height+h.maxLag < resp.ReferenceHeight {
+			resp.InSync = false
+			h.writeResponse(&h.lastStatus, http.StatusUnprocessableEntity, w, resp)
+			return
+		}
+	}
+
+	for _, api := range h.apis {
+		if err = api.CheckAPI(ctx); err != nil {
+			resp.Error = err.Error()
+			h.writeResponse(&h.lastStatus, http.StatusServiceUnavailable, w, resp)
+			return
+		}
+	}
+
+	h.writeResponse(&h.lastStatus, http.StatusOK, w, resp)
+}
+
+// Liveness is the liveness and startup check. It only requires the node's RPC to be reachable,
+// so nodes catching up or stuck are not restarted.
+func (h *Comet) Liveness(w http.ResponseWriter, r *http.Request) {
+	var resp healthResponse
+	resp.Address = h.rpcHost
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	status, err := h.client.Status(ctx, h.rpcHost)
+	if err != nil {
+		resp.Error = err.Error()
+		h.writeResponse(&h.lastLiveStatus, http.StatusServiceUnavailable, w, resp)
+		return
+	}
+	resp.InSync = !status.Result.SyncInfo.CatchingUp
+	h.writeResponse(&h.lastLiveStatus, http.StatusOK, w, resp)
+}
+
+// fetchReferenceHeight returns the max height of the references, refreshed at most every few seconds.
+// References are queried in parallel under a single referenceTimeout, apart from the probe's context, so slow
+// references do not use up the probe's timeout. Returns 0 if unknown.
+func (h *Comet) fetchReferenceHeight() uint64 {
+	h.mu.Lock()
+	if h.now().Sub(h.referenceAt) < referenceRefresh {
+		defer h.mu.Unlock()
+		return h.referenceHeight
+	}
+	h.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), referenceTimeout)
+	defer cancel()
+
+	heights := make([]uint64, len(h.references))
+	var eg errgroup.Group
+	for i, ref := range h.references {
+		eg.Go(func() error {
+			status, err := h.client.Status(ctx, ref)
+			if err != nil {
+				h.logger.V(1).Info("Failed to query reference", "reference", ref, "error", err)
+				return nil
+			}
+			heights[i] = status.LatestBlockHeight()
+			return nil
+		})
+	}
+	_ = eg.Wait()
+	height := slices.Max(heights)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.referenceHeight = height
+	h.referenceAt = h.now()
+	return height
 }
 
-func (h *Comet) writeResponse(code int, w http.ResponseWriter, resp healthResponse) {
+func (h *Comet) writeResponse(lastStatus *int32, code int, w http.ResponseWriter, resp healthResponse) {
 	w.WriteHeader(code)
 	w.Header().Set("Content-Type", "application/json")
 	mustJSONEncode(resp, w)
 	// Only log when status code changes, so we don't spam logs.
-	if atomic.SwapInt32(&h.lastStatus, int32(code)) != int32(code) {
+	if atomic.SwapInt32(lastStatus, int32(code)) != int32(code) {
 		h.logger.Info("Health state change", "statusCode", code, "response", resp)
 	}
 }
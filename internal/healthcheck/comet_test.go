@@ -20,6 +20,10 @@ func (fn mockClient) Status(ctx context.Context, rpcHost string) (cosmos.CometSt
 	return fn(ctx, rpcHost)
 }
 
+type mockAPIChecker func(ctx context.Context) error
+
+func (fn mockAPIChecker) CheckAPI(ctx context.Context) error { return fn(ctx) }
+
 var nopLogger = logr.Discard()
 
 func TestComet_ServeHTTP(t *testing.T) {
@@ -97,6 +101,194 @@ func TestComet_ServeHTTP(t *testing.T) {
 		require.Equal(t, want, got)
 	})
 
+	t.Run("reference rpcs", func(t *testing.T) {
+		const (
+			ref1 = "http://ref1:26657"
+			ref2 = "http://ref2:26657"
+		)
+		var (
+			refHeight = "120"
+			calls     int
+		)
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			var stub cosmos.CometStatus
+			switch rpcHost {
+			case testRPC:
+				stub.Result.SyncInfo.LatestBlockHeight = "100"
+			case ref1:
+				calls++
+				stub.Result.SyncInfo.LatestBlockHeight = refHeight
+			default:
+				return stub, errors.New("unreachable")
+			}
+			return stub, nil
+		})
+
+		now := time.Now()
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithReferences([]string{ref1, ref2}, 10)
+		h.now = func() time.Time { return now }
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+
+		want := healthResponse{
+			Address:         testRPC,
+			InSync:          false,
+			Height:          100,
+			ReferenceHeight: 120,
+		}
+		require.Equal(t, want, got)
+
+		// Reference height is cached.
+		refHeight = "110"
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Equal(t, 1, calls)
+
+		now = now.Add(referenceRefresh)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 2, calls)
+	})
+
+	t.Run("reference rpcs use own context", func(t *testing.T) {
+		const ref = "http://ref1:26657"
+		reqCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var (
+			refDeadline time.Time
+			refCtxErr   error
+		)
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			var stub cosmos.CometStatus
+			switch rpcHost {
+			case testRPC:
+				// Canceling the probe's context must not cancel the reference queries.
+				cancel()
+				stub.Result.SyncInfo.LatestBlockHeight = "100"
+			case ref:
+				refDeadline, _ = ctx.Deadline()
+				refCtxErr = ctx.Err()
+				stub.Result.SyncInfo.LatestBlockHeight = "105"
+			}
+			return stub, nil
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithReferences([]string{ref}, 10)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq.WithContext(reqCtx))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NoError(t, refCtxErr)
+		require.WithinDuration(t, time.Now().Add(referenceTimeout), refDeadline, time.Second)
+	})
+
+	t.Run("wrong network", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			var stub cosmos.CometStatus
+			stub.Result.NodeInfo.Network = "osmo-test-5"
+			return stub, nil
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithChainID("osmosis-1")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+
+		want := healthResponse{
+			Address: testRPC,
+			Error:   `wrong network: node reports chain ID "osmo-test-5", expected "osmosis-1"`,
+		}
+		require.Equal(t, want, got)
+
+		h = NewComet(nopLogger, client, testRPC, 10*time.Second).WithChainID("osmo-test-5")
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("max block age", func(t *testing.T) {
+		now := time.Now()
+		blockTime := now.Add(-time.Minute)
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			var stub cosmos.CometStatus
+			stub.Result.SyncInfo.LatestBlockTime = blockTime
+			return stub, nil
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithMaxBlockAge(time.Minute)
+		h.now = func() time.Time { return now }
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.True(t, got.InSync)
+		require.WithinDuration(t, blockTime, *got.LatestBlockTime, 0)
+
+		blockTime = now.Add(-61 * time.Second)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		got = healthResponse{}
+		err = json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.False(t, got.InSync)
+		require.Equal(t, "latest block is 1m1s old, exceeds max age 1m0s", got.Error)
+	})
+
+	t.Run("api errors", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, nil
+		})
+
+		var calls int
+		ok := mockAPIChecker(func(ctx context.Context) error {
+			require.NotNil(t, ctx)
+			calls++
+			return nil
+		})
+		failing := mockAPIChecker(func(context.Context) error { return errors.New("grpc: unavailable") })
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithAPIs(ok, failing)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, healthResponse{Address: testRPC, InSync: true, Error: "grpc: unavailable"}, got)
+		require.Equal(t, 1, calls)
+
+		h = NewComet(nopLogger, client, testRPC, 10*time.Second).WithAPIs(ok)
+		w = httptest.NewRecorder()
+		h.ServeHTTP(w, stubReq)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		// APIs are not checked for liveness.
+		h = NewComet(nopLogger, client, testRPC, 10*time.Second).WithAPIs(failing)
+		w = httptest.NewRecorder()
+		h.Liveness(w, stubReq)
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
 	t.Run("times out", func(t *testing.T) {
 		var gotCtx context.Context
 		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
@@ -116,3 +308,47 @@ func TestComet_ServeHTTP(t *testing.T) {
 		}
 	})
 }
+
+func TestComet_Liveness(t *testing.T) {
+	t.Parallel()
+
+	stubReq := httptest.NewRequest("GET", "/livez", nil)
+	const testRPC = "http://my-rpc:25567"
+
+	t.Run("catching up", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			require.NotNil(t, ctx)
+			require.Equal(t, testRPC, rpcHost)
+			var stub cosmos.CometStatus
+			stub.Result.SyncInfo.CatchingUp = true
+			stub.Result.SyncInfo.LatestBlockTime = time.Now().Add(-time.Hour)
+			return stub, nil
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second).WithMaxBlockAge(time.Minute)
+		w := httptest.NewRecorder()
+		h.Liveness(w, stubReq)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, healthResponse{Address: testRPC}, got)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, errors.New("boom")
+		})
+
+		h := NewComet(nopLogger, client, testRPC, 10*time.Second)
+		w := httptest.NewRecorder()
+		h.Liveness(w, stubReq)
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		var got healthResponse
+		err := json.NewDecoder(w.Body).Decode(&got)
+		require.NoError(t, err)
+		require.Equal(t, healthResponse{Address: testRPC, Error: "boom"}, got)
+	})
+}
@@ -2,3 +2,10 @@ package healthcheck
 
 // Port is the port for the healthcheck sidecar.
 const Port = 1251
+
+// Paths of the healthcheck sidecar's probe endpoints.
+const (
+	ReadinessPath = "/readyz"
+	LivenessPath  = "/livez"
+	StartupPath   = "/startupz"
+)
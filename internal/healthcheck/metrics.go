@@ -0,0 +1,187 @@
+package healthcheck
+
+import (
+	"context"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// StoreDirs are the directories, relative to the chain home, whose sizes are exported.
+var StoreDirs = []string{
+	"data/application.db",
+	"data/blockstore.db",
+	"data/state.db",
+	"data/tx_index.db",
+	"wasm",
+}
+
+// storeSizeRefresh is how often store sizes are recomputed. Walking large stores is too slow for every scrape.
+const storeSizeRefresh = 5 * time.Minute
+
+var (
+	diskTotalBytesDesc  = newDesc("disk_total_bytes", "Size of the filesystem holding the chain home.")
+	diskFreeBytesDesc   = newDesc("disk_free_bytes", "Free bytes of the filesystem holding the chain home.")
+	diskTotalInodesDesc = newDesc("disk_total_inodes", "Inodes of the filesystem holding the chain home.")
+	diskFreeInodesDesc  = newDesc("disk_free_inodes", "Free inodes of the filesystem holding the chain home.")
+	storeSizeDesc       = newDesc("store_size_bytes", "Size of a store directory within the chain home.", "store")
+
+	rpcUpDesc           = newDesc("rpc_up", "1 if the node's CometBFT RPC responded to status, otherwise 0.")
+	rpcLatencyDesc      = newDesc("rpc_latency_seconds", "Duration of the node's CometBFT RPC status request.")
+	catchingUpDesc      = newDesc("catching_up", "1 if the node reports it is catching up, otherwise 0.")
+	latestHeightDesc    = newDesc("latest_block_height", "Latest block height of the node.")
+	latestBlockTimeDesc = newDesc("latest_block_time_seconds", "Unix time of the node's latest block.")
+)
+
+func newDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName("cosmos", "node", name), help, labels, nil)
+}
+
+// Metrics is a prometheus.Collector exporting the node's disk usage, store sizes, and sync status.
+// Values are gathered when scraped.
+type Metrics struct {
+	client  Statuser
+	rpcHost string
+	homeDir string
+	timeout time.Duration
+
+	now     func() time.Time
+	statfs  func(path string, buf *syscall.Statfs_t) error
+	dirSize func(dir string) (uint64, error)
+
+	mu           sync.Mutex
+	storeSizes   map[string]uint64
+	storeSizesAt time.Time
+}
+
+// NewMetrics returns Metrics for the node at rpcHost. If homeDir is empty, disk metrics are not exported.
+func NewMetrics(client Statuser, rpcHost, homeDir string, timeout time.Duration) *Metrics {
+	return &Metrics{
+		client:  client,
+		rpcHost: rpcHost,
+		homeDir: homeDir,
+		timeout: timeout,
+		now:     time.Now,
+		statfs:  syscall.Statfs,
+		dirSize: dirSize,
+	}
+}
+
+// Handler returns the http.Handler serving the metrics in the prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(m)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		diskTotalBytesDesc, diskFreeBytesDesc, diskTotalInodesDesc, diskFreeInodesDesc, storeSizeDesc,
+		rpcUpDesc, rpcLatencyDesc, catchingUpDesc, latestHeightDesc, latestBlockTimeDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.collectStatus(ch)
+	if m.homeDir == "" {
+		return
+	}
+	m.collectDisk(ch)
+	for store, size := range m.currentStoreSizes() {
+		ch <- prometheus.MustNewConstMetric(storeSizeDesc, prometheus.GaugeValue, float64(size), store)
+	}
+}
+
+func (m *Metrics) collectStatus(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	start := m.now()
+	status, err := m.client.Status(ctx, m.rpcHost)
+	ch <- prometheus.MustNewConstMetric(rpcLatencyDesc, prometheus.GaugeValue, m.now().Sub(start).Seconds())
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(rpcUpDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(rpcUpDesc, prometheus.GaugeValue, 1)
+	ch <- prometheus.MustNewConstMetric(catchingUpDesc, prometheus.GaugeValue, boolToFloat(status.Result.SyncInfo.CatchingUp))
+	ch <- prometheus.MustNewConstMetric(latestHeightDesc, prometheus.GaugeValue, float64(status.LatestBlockHeight()))
+	if blockTime := status.Result.SyncInfo.LatestBlockTime; !blockTime.IsZero() {
+		ch <- prometheus.MustNewConstMetric(latestBlockTimeDesc, prometheus.GaugeValue, float64(blockTime.Unix()))
+	}
+}
+
+func (m *Metrics) collectDisk(ch chan<- prometheus.Metric) {
+	var stat syscall.Statfs_t
+	if err := m.statfs(filepath.Clean(m.homeDir), &stat); err != nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(diskTotalBytesDesc, prometheus.GaugeValue, float64(stat.Blocks*uint64(stat.Bsize)))
+	ch <- prometheus.MustNewConstMetric(diskFreeBytesDesc, prometheus.GaugeValue, float64(stat.Bfree*uint64(stat.Bsize)))
+	ch <- prometheus.MustNewConstMetric(diskTotalInodesDesc, prometheus.GaugeValue, float64(stat.Files))
+	ch <- prometheus.MustNewConstMetric(diskFreeInodesDesc, prometheus.GaugeValue, float64(stat.Ffree))
+}
+
+// currentStoreSizes returns the size of each StoreDirs keyed by its base name, recomputed at most every few minutes.
+// Missing stores are omitted.
+func (m *Metrics) currentStoreSizes() map[string]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if m.storeSizes != nil && now.Sub(m.storeSizesAt) < storeSizeRefresh {
+		return m.storeSizes
+	}
+	sizes := make(map[string]uint64)
+	for _, dir := range StoreDirs {
+		size, err := m.dirSize(filepath.Join(m.homeDir, dir))
+		if err != nil {
+			continue
+		}
+		sizes[filepath.Base(dir)] = size
+	}
+	m.storeSizes = sizes
+	m.storeSizesAt = now
+	return sizes
+}
+
+// dirSize returns the total size of regular files within dir.
+func dirSize(dir string) (uint64, error) {
+	var total uint64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir {
+				return err
+			}
+			// The node may delete files during the walk, e.g. during compaction.
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		total += uint64(info.Size())
+		return nil
+	})
+	return total, err
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,127 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	const testRPC = "http://localhost:26657"
+
+	scrape := func(t *testing.T, m *Metrics) string {
+		w := httptest.NewRecorder()
+		m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+		require.Equal(t, 200, w.Code)
+		b, err := io.ReadAll(w.Body)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		blockTime := time.Unix(1700000000, 0)
+		client := mockClient(func(ctx context.Context, rpcHost string) (cosmos.CometStatus, error) {
+			_, ok := ctx.Deadline()
+			require.True(t, ok)
+			require.Equal(t, testRPC, rpcHost)
+			var status cosmos.CometStatus
+			status.Result.SyncInfo.LatestBlockHeight = "1234"
+			status.Result.SyncInfo.LatestBlockTime = blockTime
+			status.Result.SyncInfo.CatchingUp = true
+			return status, nil
+		})
+
+		m := NewMetrics(client, testRPC, "/home/operator/cosmos", time.Second)
+		now := time.Now()
+		m.now = func() time.Time { return now }
+		m.statfs = func(path string, buf *syscall.Statfs_t) error {
+			require.Equal(t, "/home/operator/cosmos", path)
+			buf.Blocks = 100
+			buf.Bfree = 25
+			buf.Bsize = 4096
+			buf.Files = 1000
+			buf.Ffree = 900
+			return nil
+		}
+		var walked []string
+		m.dirSize = func(dir string) (uint64, error) {
+			walked = append(walked, dir)
+			if filepath.Base(dir) == "wasm" {
+				return 0, os.ErrNotExist
+			}
+			return 2048, nil
+		}
+
+		got := scrape(t, m)
+
+		require.Contains(t, got, "cosmos_node_rpc_up 1\n")
+		require.Contains(t, got, "cosmos_node_rpc_latency_seconds 0\n")
+		require.Contains(t, got, "cosmos_node_catching_up 1\n")
+		require.Contains(t, got, "cosmos_node_latest_block_height 1234\n")
+		require.Contains(t, got, "cosmos_node_latest_block_time_seconds 1.7e+09\n")
+		require.Contains(t, got, "cosmos_node_disk_total_bytes 409600\n")
+		require.Contains(t, got, "cosmos_node_disk_free_bytes 102400\n")
+		require.Contains(t, got, "cosmos_node_disk_total_inodes 1000\n")
+		require.Contains(t, got, "cosmos_node_disk_free_inodes 900\n")
+		for _, store := range []string{"application.db", "blockstore.db", "state.db", "tx_index.db"} {
+			require.Contains(t, got, `cosmos_node_store_size_bytes{store="`+store+`"} 2048`+"\n")
+		}
+		require.NotContains(t, got, `store="wasm"`)
+		require.Equal(t, []string{
+			"/home/operator/cosmos/data/application.db",
+			"/home/operator/cosmos/data/blockstore.db",
+			"/home/operator/cosmos/data/state.db",
+			"/home/operator/cosmos/data/tx_index.db",
+			"/home/operator/cosmos/wasm",
+		}, walked)
+
+		// Store sizes are cached.
+		scrape(t, m)
+		require.Len(t, walked, 5)
+
+		now = now.Add(storeSizeRefresh)
+		scrape(t, m)
+		require.Len(t, walked, 10)
+	})
+
+	t.Run("rpc error and no home", func(t *testing.T) {
+		client := mockClient(func(context.Context, string) (cosmos.CometStatus, error) {
+			return cosmos.CometStatus{}, errors.New("boom")
+		})
+		m := NewMetrics(client, testRPC, "", time.Second)
+		m.statfs = func(string, *syscall.Statfs_t) error { panic("should not be called") }
+
+		got := scrape(t, m)
+
+		require.Contains(t, got, "cosmos_node_rpc_up 0\n")
+		require.NotContains(t, got, "cosmos_node_latest_block_height")
+		require.NotContains(t, got, "cosmos_node_disk")
+	})
+}
+
+func TestDirSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), make([]byte, 10), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), make([]byte, 5), 0o600))
+
+	got, err := dirSize(dir)
+	require.NoError(t, err)
+	require.EqualValues(t, 15, got)
+
+	_, err = dirSize(filepath.Join(dir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -16,9 +16,18 @@ const (
 	VersionLabel    = "app.kubernetes.io/version"
 	ComponentLabel  = "app.kubernetes.io/component"
 
+	// RevisionLabel is a hash of the resource, used to detect changes.
+	RevisionLabel = "app.kubernetes.io/revision"
+
 	// OrdinalAnnotation is used to order resources. The value must be a base 10 integer string.
 	OrdinalAnnotation = "app.kubernetes.io/ordinal"
 
+	// ChainIDAnnotation is the chain ID the pod's node is expected to report as its network.
+	ChainIDAnnotation = "cosmos.strange.love/chain-id"
+
+	// VersionReportAnnotation is the JSON height and upgrade the version check reports for the pod's stopped node.
+	VersionReportAnnotation = "cosmos.strange.love/version-report"
+
 	BelongsToLabel = "cosmos.strange.love/belongs-to"
 )
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"strconv"
 	"time"
 
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
@@ -11,6 +12,21 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Annotations describing the chain state captured by a VolumeSnapshot.
+const (
+	SnapshotHeightAnnotation  = "cosmos.strange.love/block-height"
+	SnapshotAppHashAnnotation = "cosmos.strange.love/app-hash"
+	SnapshotChainIDAnnotation = ChainIDAnnotation
+	SnapshotImageAnnotation   = "cosmos.strange.love/image"
+)
+
+// VolumeSnapshotHeight returns the block height recorded in the snapshot's annotations.
+// Returns 0 if the height is missing or malformed.
+func VolumeSnapshotHeight(snapshot snapshotv1.VolumeSnapshot) uint64 {
+	h, _ := strconv.ParseUint(snapshot.Annotations[SnapshotHeightAnnotation], 10, 64)
+	return h
+}
+
 // VolumeSnapshotIsReady returns true if the snapshot is ready to use.
 func VolumeSnapshotIsReady(status *snapshotv1.VolumeSnapshotStatus) bool {
 	if status == nil {
@@ -28,9 +44,11 @@ type Lister interface {
 }
 
 // RecentVolumeSnapshot finds the most recent, ready to use VolumeSnapshot.
+// Snapshots with the highest recorded block height are preferred. Ties, or snapshots without a recorded height,
+// fall back to the most recent creation time. Only snapshots passing all filters are considered.
 // This function may not work well given very large lists and therefore assumes a reasonable number of VolumeSnapshots.
 // If you must search among many VolumeSnapshots, consider refactoring to use Limit and Continue features of listing.
-func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string, selector map[string]string) (*snapshotv1.VolumeSnapshot, error) {
+func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string, selector map[string]string, filters ...func(snapshotv1.VolumeSnapshot) bool) (*snapshotv1.VolumeSnapshot, error) {
 	var snapshots snapshotv1.VolumeSnapshotList
 	err := lister.List(ctx,
 		&snapshots,
@@ -42,16 +60,28 @@ func RecentVolumeSnapshot(ctx context.Context, lister Lister, namespace string,
 	}
 
 	filtered := lo.Filter(snapshots.Items, func(s snapshotv1.VolumeSnapshot, _ int) bool {
-		return VolumeSnapshotIsReady(s.Status)
+		if !VolumeSnapshotIsReady(s.Status) {
+			return false
+		}
+		for _, keep := range filters {
+			if !keep(s) {
+				return false
+			}
+		}
+		return true
 	})
 	if len(filtered) == 0 {
 		return nil, errors.New("no ready to use VolumeSnapshots found")
 	}
 
-	sort.Slice(filtered, func(i, j int) bool {
+	sort.SliceStable(filtered, func(i, j int) bool {
+		lhsHeight, rhsHeight := VolumeSnapshotHeight(filtered[i]), VolumeSnapshotHeight(filtered[j])
+		if lhsHeight != rhsHeight {
+			return lhsHeight > rhsHeight
+		}
 		lhs := statusCreationTime(filtered[i].Status)
 		rhs := statusCreationTime(filtered[j].Status)
-		return !lhs.Before(rhs)
+		return lhs.After(rhs)
 	})
 
 	found := &filtered[0]
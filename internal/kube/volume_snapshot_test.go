@@ -92,6 +92,53 @@ func TestRecentVolumeSnapshot(t *testing.T) {
 		require.Equal(t, snap1.Name, got.Name)
 	})
 
+	t.Run("prefers highest height", func(t *testing.T) {
+		now := metav1.Now()
+		newest := snapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "newest"},
+			Status: &snapshotv1.VolumeSnapshotStatus{
+				CreationTime: ptr(now),
+				ReadyToUse:   ptr(true),
+			},
+		}
+
+		highest := *newest.DeepCopy()
+		highest.Name = "highest"
+		highest.Annotations = map[string]string{SnapshotHeightAnnotation: "1000"}
+		highest.Status.CreationTime = ptr(metav1.NewTime(now.Add(-time.Hour)))
+
+		lower := *newest.DeepCopy()
+		lower.Name = "lower"
+		lower.Annotations = map[string]string{SnapshotHeightAnnotation: "999"}
+
+		otherChain := *newest.DeepCopy()
+		otherChain.Name = "other-chain"
+		otherChain.Annotations = map[string]string{SnapshotHeightAnnotation: "5000", SnapshotChainIDAnnotation: "other-1"}
+
+		var list snapshotv1.VolumeSnapshotList
+		list.Items = lo.Shuffle([]snapshotv1.VolumeSnapshot{newest, highest, lower, otherChain})
+
+		lister := mockLister(func(ctx context.Context, inList client.ObjectList, opts ...client.ListOption) error {
+			ref := inList.(*snapshotv1.VolumeSnapshotList)
+			*ref = list
+			return nil
+		})
+
+		got, err := RecentVolumeSnapshot(ctx, lister, namespace, selector)
+		require.NoError(t, err)
+		require.Equal(t, "other-chain", got.Name)
+
+		sameChain := func(s snapshotv1.VolumeSnapshot) bool {
+			return s.Annotations[SnapshotChainIDAnnotation] == ""
+		}
+		got, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, sameChain)
+		require.NoError(t, err)
+		require.Equal(t, "highest", got.Name)
+
+		_, err = RecentVolumeSnapshot(ctx, lister, namespace, selector, func(snapshotv1.VolumeSnapshot) bool { return false })
+		require.EqualError(t, err, "no ready to use VolumeSnapshots found")
+	})
+
 	t.Run("no items", func(t *testing.T) {
 		var list snapshotv1.VolumeSnapshotList
 		lister := mockLister(func(ctx context.Context, inList client.ObjectList, opts ...client.ListOption) error {
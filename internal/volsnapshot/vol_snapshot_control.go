@@ -5,12 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	"github.com/samber/lo"
 	cosmosalpha "github.com/strangelove-ventures/cosmos-operator/api/v1alpha1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
 	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +29,10 @@ type Client interface {
 	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
 }
 
+// PodFilter finds pods and their CometBFT status.
 type PodFilter interface {
 	SyncedPods(ctx context.Context, controller client.ObjectKey) []*corev1.Pod
+	Collect(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection
 }
 
 // VolumeSnapshotControl manages VolumeSnapshots
@@ -55,7 +59,8 @@ func (control VolumeSnapshotControl) FindCandidate(ctx context.Context, crd *cos
 	cctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 	var (
-		synced     = control.podFilter.SyncedPods(cctx, client.ObjectKey{Namespace: crd.Namespace, Name: crd.Spec.FullNodeRef.Name})
+		key        = client.ObjectKey{Namespace: crd.Namespace, Name: crd.Spec.FullNodeRef.Name}
+		synced     = control.podFilter.SyncedPods(cctx, key)
 		availCount = int32(len(synced))
 		minAvail   = crd.Spec.MinAvailable
 	)
@@ -85,11 +90,31 @@ func (control VolumeSnapshotControl) FindCandidate(ctx context.Context, crd *cos
 		pod = synced[0]
 	}
 
-	return Candidate{
+	candidate := Candidate{
 		PodLabels: pod.Labels,
 		PodName:   pod.Name,
 		PVCName:   fullnode.PVCName(pod),
-	}, nil
+		Image:     fullnode.ChainContainerImage(pod),
+	}
+
+	// Record the chain state so consumers of the VolumeSnapshot can judge its data.
+	// If the pod is deleted prior to creating the snapshot, the node may sync a few more blocks;
+	// therefore, the height is a lower bound.
+	for _, item := range control.podFilter.Collect(cctx, key) {
+		if item.GetPod().Name != pod.Name {
+			continue
+		}
+		status, err := item.GetStatus()
+		if err != nil {
+			break
+		}
+		candidate.Height = status.LatestBlockHeight()
+		candidate.AppHash = status.Result.SyncInfo.LatestAppHash
+		candidate.ChainID = status.Result.NodeInfo.Network
+		break
+	}
+
+	return candidate, nil
 }
 
 // CreateSnapshot creates VolumeSnapshot from the Candidate.PVCName and updates crd.status to reflect the created VolumeSnapshot.
@@ -114,6 +139,20 @@ func (control VolumeSnapshotControl) CreateSnapshot(ctx context.Context, crd *co
 	snapshot.Labels[kube.ControllerLabel] = "cosmos-operator"
 	snapshot.Labels[cosmosSourceLabel] = crd.Name
 
+	snapshot.Annotations = make(map[string]string)
+	if candidate.Height > 0 {
+		snapshot.Annotations[kube.SnapshotHeightAnnotation] = strconv.FormatUint(candidate.Height, 10)
+	}
+	if candidate.AppHash != "" {
+		snapshot.Annotations[kube.SnapshotAppHashAnnotation] = candidate.AppHash
+	}
+	if candidate.ChainID != "" {
+		snapshot.Annotations[kube.SnapshotChainIDAnnotation] = candidate.ChainID
+	}
+	if candidate.Image != "" {
+		snapshot.Annotations[kube.SnapshotImageAnnotation] = candidate.Image
+	}
+
 	if err := control.client.Create(ctx, &snapshot); err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/samber/lo"
 	cosmosv1 "github.com/strangelove-ventures/cosmos-operator/api/v1"
 	cosmosalpha "github.com/strangelove-ventures/cosmos-operator/api/v1alpha1"
+	"github.com/strangelove-ventures/cosmos-operator/internal/cosmos"
 	"github.com/strangelove-ventures/cosmos-operator/internal/fullnode"
 	"github.com/strangelove-ventures/cosmos-operator/internal/kube"
 	"github.com/stretchr/testify/require"
@@ -56,6 +57,7 @@ func (m *mockPodClient) Delete(ctx context.Context, obj client.Object, opts ...c
 
 type mockPodFilter struct {
 	SyncedPodsFn func(ctx context.Context, controller client.ObjectKey) []*corev1.Pod
+	CollectFn    func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection
 }
 
 func (fn mockPodFilter) SyncedPods(ctx context.Context, controller client.ObjectKey) []*corev1.Pod {
@@ -68,6 +70,16 @@ func (fn mockPodFilter) SyncedPods(ctx context.Context, controller client.Object
 	return fn.SyncedPodsFn(ctx, controller)
 }
 
+func (fn mockPodFilter) Collect(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+	if ctx == nil {
+		panic("nil context")
+	}
+	if fn.CollectFn == nil {
+		return nil
+	}
+	return fn.CollectFn(ctx, controller)
+}
+
 var (
 	panicFilter mockPodFilter
 	nopLogger   = logr.Discard()
@@ -121,6 +133,42 @@ func TestVolumeSnapshotControl_FindCandidate(t *testing.T) {
 		require.Equal(t, "pvc-cosmoshub-1", got.PVCName)
 		require.NotEmpty(t, got.PodLabels)
 		require.Equal(t, candidate.Labels, got.PodLabels)
+		require.Zero(t, got.Height)
+	})
+
+	t.Run("records chain state", func(t *testing.T) {
+		var fullnodeCRD cosmosv1.CosmosFullNode
+		fullnodeCRD.Name = fullNodeName
+		fullnodeCRD.Spec.PodTemplate.Image = "ghcr.io/cosmoshub:v1"
+		candidate, err := fullnode.NewPodBuilder(&fullnodeCRD).WithOrdinal(0).Build()
+		require.NoError(t, err)
+
+		var status cosmos.CometStatus
+		status.Result.SyncInfo.LatestBlockHeight = "12345"
+		status.Result.SyncInfo.LatestAppHash = "ABC123"
+		status.Result.NodeInfo.Network = "cosmoshub-4"
+
+		control := NewVolumeSnapshotControl(&mockPodClient{}, mockPodFilter{
+			SyncedPodsFn: func(context.Context, client.ObjectKey) []*corev1.Pod {
+				return []*corev1.Pod{candidate, new(corev1.Pod)}
+			},
+			CollectFn: func(ctx context.Context, controller client.ObjectKey) cosmos.StatusCollection {
+				require.Equal(t, client.ObjectKey{Namespace: namespace, Name: fullNodeName}, controller)
+				return cosmos.StatusCollection{
+					{Pod: new(corev1.Pod), Err: errors.New("should not be used")},
+					{Pod: candidate, Status: status},
+				}
+			},
+		})
+
+		got, err := control.FindCandidate(ctx, &crd)
+		require.NoError(t, err)
+
+		require.Equal(t, "cosmoshub-0", got.PodName)
+		require.EqualValues(t, 12345, got.Height)
+		require.Equal(t, "ABC123", got.AppHash)
+		require.Equal(t, "cosmoshub-4", got.ChainID)
+		require.Equal(t, "ghcr.io/cosmoshub:v1", got.Image)
 	})
 
 	t.Run("happy path with index", func(t *testing.T) {
@@ -269,6 +317,10 @@ func TestVolumeSnapshotControl_CreateSnapshot(t *testing.T) {
 			PodLabels: labels,
 			PodName:   "chain-1",
 			PVCName:   "pvc-chain-1",
+			Height:    12345,
+			AppHash:   "ABC123",
+			ChainID:   "cosmoshub-4",
+			Image:     "ghcr.io/cosmoshub:v1",
 		}
 		err := control.CreateSnapshot(ctx, &crd, candidate)
 
@@ -292,6 +344,14 @@ func TestVolumeSnapshotControl_CreateSnapshot(t *testing.T) {
 		}
 		require.Equal(t, wantLabels, got.Labels)
 
+		wantAnnotations := map[string]string{
+			kube.SnapshotHeightAnnotation:  "12345",
+			kube.SnapshotAppHashAnnotation: "ABC123",
+			kube.SnapshotChainIDAnnotation: "cosmoshub-4",
+			kube.SnapshotImageAnnotation:   "ghcr.io/cosmoshub:v1",
+		}
+		require.Equal(t, wantAnnotations, got.Annotations)
+
 		wantStatus := &cosmosalpha.VolumeSnapshotStatus{
 			Name:      wantName,
 			StartedAt: metav1.NewTime(now),
@@ -318,6 +378,7 @@ func TestVolumeSnapshotControl_CreateSnapshot(t *testing.T) {
 			cosmosSourceLabel:    "cosmoshub",
 		}
 		require.Equal(t, wantLabels, got.Labels)
+		require.Empty(t, got.Annotations)
 	})
 
 	t.Run("create error", func(t *testing.T) {
@@ -82,6 +82,8 @@ var (
 	profileMode          string
 	logLevel             string
 	logFormat            string
+	blockSubscriptions   bool
+	statusMaxInFlight    int
 )
 
 func rootCmd() *cobra.Command {
@@ -101,6 +103,10 @@ func rootCmd() *cobra.Command {
 	root.Flags().StringVar(&profileMode, "profile", "", "Enable profiling and save profile to working dir. (Must be one of 'cpu', or 'mem'.)")
 	root.Flags().StringVar(&logLevel, "log-level", "info", "Logging level one of 'error', 'info', 'debug'")
 	root.Flags().StringVar(&logFormat, "log-format", "console", "Logging format one of 'console' or 'json'")
+	root.Flags().BoolVar(&blockSubscriptions, "block-subscriptions", false,
+		"Subscribe to NewBlock events on each pod's CometBFT websocket to update sync status as blocks arrive. "+
+			"Falls back to polling pods without a working subscription.")
+	root.Flags().IntVar(&statusMaxInFlight, "status-max-in-flight", 50, "Maximum concurrent CometBFT status requests across all pods.")
 
 	if err := viper.BindPFlags(root.Flags()); err != nil {
 		panic(err)
@@ -109,6 +115,8 @@ func rootCmd() *cobra.Command {
 	// Add subcommands here
 	root.AddCommand(opcmd.HealthCheckCmd())
 	root.AddCommand(opcmd.VersionCheckCmd(scheme))
+	root.AddCommand(opcmd.RenderCmd(scheme))
+	root.AddCommand(opcmd.FleetCmd(scheme))
 	root.AddCommand(&cobra.Command{
 		Short: "Print the version",
 		Use:   "version",
@@ -122,6 +130,10 @@ func rootCmd() *cobra.Command {
 }
 
 func startManager(cmd *cobra.Command, args []string) error {
+	if statusMaxInFlight < 1 {
+		return fmt.Errorf("--status-max-in-flight must be at least 1")
+	}
+
 	logger := opcmd.ZapLogger(logLevel, logFormat)
 	defer func() { _ = logger.Sync() }()
 	ctrl.SetLogger(zapr.NewLogger(logger))
@@ -166,10 +178,14 @@ func startManager(cmd *cobra.Command, args []string) error {
 	statusClient := fullnode.NewStatusClient(mgr.GetClient())
 	cometClient := cosmos.NewCometClient(httpClient)
 	cacheController := cosmos.NewCacheController(
-		cosmos.NewStatusCollector(cometClient, 5*time.Second),
+		cosmos.NewStatusCollector(cometClient, 5*time.Second, statusMaxInFlight),
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(cosmos.CacheControllerName),
 	)
+	if blockSubscriptions {
+		cacheController.SubscribeNewBlocks(cometClient)
+	}
+	cacheController.PollReferences(cometClient)
 	defer func() { _ = cacheController.Close() }()
 	if err = cacheController.SetupWithManager(ctx, mgr); err != nil {
 		return fmt.Errorf("unable to create CosmosCache controller: %w", err)